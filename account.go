@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// 여러 Steam 계정의 세이브 처리
+// 세이브 폴더(SaveGames) 아래 Steam ID 폴더마다 세이브가 따로 있음
+// 프로필의 watch_all_accounts를 켜면 모든 계정의 세이브를 감시하고 백업을 backup_dir/<Steam ID>에 나눠 저장
+
+// accountOf 세이브 파일이 들어 있는 Steam ID 폴더 이름
+func accountOf(targetFile string) string {
+	account := filepath.Base(filepath.Dir(targetFile))
+	if !isNumeric(account) {
+		return ""
+	}
+	return account
+}
+
+// accountTargets 프로필 세이브와 같은 세이브 폴더에 있는 다른 Steam ID의 세이브
+func accountTargets(p *Profile, primary saveTarget) []saveTarget {
+	var targets []saveTarget
+	saveGames := filepath.Dir(filepath.Dir(p.targetFile))
+	paths, _ := filepath.Glob(filepath.Join(saveGames, "*", filepath.Base(p.targetFile)))
+	for _, path := range paths {
+		account := accountOf(path)
+		if account == "" || account == primary.Account {
+			continue
+		}
+		target := primary
+		target.Account = account
+		target.TargetFile = path
+		target.BackupDir = filepath.Join(p.backupDir, account)
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+var (
+	accountNamesMu sync.Mutex
+	accountNames   map[string]string
+	personaPattern = regexp.MustCompile(`"(\d+)"\s*\{[^}]*?"PersonaName"\s+"([^"]*)"`)
+
+	steamUserPattern  = regexp.MustCompile(`"(\d+)"\s*\{([^}]*)\}`)
+	mostRecentPattern = regexp.MustCompile(`"MostRecent"\s+"1"`)
+)
+
+// accountName 표시용 계정 이름. Steam의 loginusers.vdf에 있으면 프로필 이름, 없으면 Steam ID
+func accountName(account string) string {
+	if name := steamAccountName(account); name != "" {
+		return name
+	}
+	return account
+}
+
+// activeSteamID 마지막으로 로그인한 Steam 계정의 Steam ID (loginusers.vdf의 MostRecent, 계정이 하나뿐이면 그 계정)
+func activeSteamID() string {
+	for _, dir := range steamInstallDirs() {
+		data, err := os.ReadFile(filepath.Join(dir, "config", "loginusers.vdf"))
+		if err != nil {
+			continue
+		}
+		users := steamUserPattern.FindAllStringSubmatch(string(data), -1)
+		for _, user := range users {
+			if mostRecentPattern.MatchString(user[2]) {
+				return user[1]
+			}
+		}
+		if len(users) == 1 {
+			return users[0][1]
+		}
+	}
+	return ""
+}
+
+// steamAccountName loginusers.vdf에 기록된 Steam 프로필 이름 (없으면 빈 문자열)
+func steamAccountName(account string) string {
+	if account == "" {
+		return ""
+	}
+
+	accountNamesMu.Lock()
+	defer accountNamesMu.Unlock()
+
+	if accountNames == nil {
+		accountNames = make(map[string]string)
+		for _, dir := range steamInstallDirs() {
+			data, err := os.ReadFile(filepath.Join(dir, "config", "loginusers.vdf"))
+			if err != nil {
+				continue
+			}
+			for _, match := range personaPattern.FindAllStringSubmatch(string(data), -1) {
+				accountNames[match[1]] = match[2]
+			}
+		}
+	}
+
+	return accountNames[account]
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// hotkeyActions 단축키에 연결할 수 있는 동작. 프로필 단축키는 그 프로필, 나머지는 기본 프로필 대상
+// 동작 이름 뒤에 ":인자"를 붙일 수 있음 (예: "backup_labeled:checkpoint 1")
+var hotkeyActions = map[string]func(p *Profile, arg string){
+	"backup":                actionBackup,
+	"backup_labeled":        actionBackupLabeled,
+	"restore_latest":        func(p *Profile, _ string) { actionRestoreLatest(p) },
+	"toggle_auto_backup":    func(*Profile, string) { toggleAutoBackup() },
+	"pause_auto_backup_15m": func(*Profile, string) { pauseAutoBackup(15 * time.Minute) },
+	"open_backup_folder":    func(p *Profile, _ string) { openBackupFolder(p) },
+	"quick_save":            func(p *Profile, _ string) { quickSave(p) },
+	"quick_load":            func(p *Profile, _ string) { quickLoad(p) },
+	"quick_next":            func(p *Profile, _ string) { selectQuickSlot(p, 1) },
+	"quick_prev":            func(p *Profile, _ string) { selectQuickSlot(p, -1) },
+}
+
+// profileAction 프로필 단축키의 동작 이름 앞에 프로필 이름 붙이기 ("[프로필] 동작")
+func profileAction(profile, action string) string {
+	return fmt.Sprintf("[%s] %s", profile, action)
+}
+
+// splitProfileAction "[프로필] 동작"을 프로필 이름과 동작으로 분리 (프로필이 없으면 빈 문자열)
+func splitProfileAction(action string) (profile, rest string) {
+	if strings.HasPrefix(action, "[") {
+		if end := strings.Index(action, "]"); end > 0 {
+			return action[1:end], strings.TrimSpace(action[end+1:])
+		}
+	}
+	return "", action
+}
+
+// splitAction "동작:인자" 형식을 동작 이름과 인자로 분리
+func splitAction(action string) (name, arg string) {
+	_, action = splitProfileAction(action)
+	name, arg, _ = strings.Cut(action, ":")
+	return strings.TrimSpace(name), strings.TrimSpace(arg)
+}
+
+// validateAction 알 수 없는 동작이면 오류 반환
+func validateAction(action string) error {
+	name, _ := splitAction(action)
+	if _, ok := hotkeyActions[name]; !ok {
+		return fmt.Errorf("알 수 없는 동작입니다: %s", name)
+	}
+	return nil
+}
+
+func runAction(action string) {
+	profileName, _ := splitProfileAction(action)
+	name, arg := splitAction(action)
+	handler, ok := hotkeyActions[name]
+	if !ok {
+		log.Printf("알 수 없는 동작입니다: %s", name)
+		return
+	}
+
+	profile := defaultProfile()
+	if profileName != "" {
+		if profile = findProfile(profileName); profile == nil {
+			log.Printf("프로필을 찾을 수 없습니다: %s", profileName)
+			return
+		}
+	}
+	handler(profile, arg)
+}
+
+func actionBackup(p *Profile, label string) {
+	backupPath, err := createBackup(p.primaryTarget(), triggerHotkey, label)
+	if err != nil {
+		log.Printf("단축키 백업 실패: %v", err)
+		return
+	}
+	log.Printf("단축키 백업 완료: %s", backupPath)
+}
+
+// actionBackupLabeled 라벨을 붙인 백업. 인자가 없으면 라벨을 입력받음
+func actionBackupLabeled(p *Profile, label string) {
+	if label == "" {
+		input, ok := promptText("라벨 백업", "백업에 붙일 라벨을 입력하세요")
+		if !ok {
+			log.Println("라벨 백업 취소")
+			return
+		}
+		label = input
+	}
+	actionBackup(p, label)
+}
+
+func actionRestoreLatest(p *Profile) {
+	backupPath, err := latestBackupPath(p.primaryTarget())
+	if err != nil {
+		log.Printf("최근 백업 복원 실패: %v", err)
+		return
+	}
+
+	if err := restoreBackup(backupPath); err != nil {
+		log.Printf("최근 백업 복원 실패: %v", err)
+		return
+	}
+	log.Printf("최근 백업 복원 완료: %s", backupPath)
+}
+
+// toggleAutoBackup 자동 백업 켜기/끄기를 설정 파일에 저장
+func toggleAutoBackup() {
+	cfg := GetConfig().clone()
+	cfg.AutoBackup = !cfg.AutoBackup
+	if err := saveConfig(cfg); err != nil {
+		log.Printf("설정 저장 실패: %v", err)
+		return
+	}
+	log.Printf("자동 백업: %v", cfg.AutoBackup)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -8,39 +9,259 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
-func performAutoBackup() {
-	if !GetConfig().AutoBackup {
+var (
+	autoPauseMu      sync.Mutex
+	autoPauseUntil   time.Time
+	autoPauseForGame bool // 게임 종료 시까지 중지
+	autoPauseTimer   *time.Timer
+)
+
+func performAutoBackup(target saveTarget) {
+	cfg := GetConfig()
+	if !cfg.AutoBackup {
+		return
+	}
+
+	if paused, until := autoBackupPauseStatus(); paused {
+		log.Printf("자동 백업 일시 중지 중 (%s)", until)
 		return
 	}
 
-	sourceFile := GetConfig().TargetFile
-	if _, err := os.Stat(sourceFile); os.IsNotExist(err) {
-		log.Printf("백업할 파일이 존재하지 않습니다: %s", sourceFile)
+	// 게임 실행 중에만 자동 백업하도록 설정된 경우 (프로필의 게임 기준)
+	if cfg.AutoBackupInGameOnly && !isProfileGameRunning(target.Profile.Name) {
+		log.Println("게임이 실행 중이 아니므로 자동 백업을 건너뜁니다")
 		return
 	}
 
-	backupDir := GetConfig().BackupDir
-	autoBackup0 := filepath.Join(backupDir, "StellarBladeSave00_auto_0.sav")
-	autoBackup1 := filepath.Join(backupDir, "StellarBladeSave00_auto_1.sav")
+	done := reportBusy()
+	autoBackup0, err := createAutoBackup(target)
+	done(err)
+	if err != nil {
+		log.Printf("자동 백업 실패: %v", err)
+		if !errors.Is(err, errSaveQuarantined) {
+			notify(notifyFailure, "자동 백업 실패", targetMessage(target, err.Error()))
+		}
+		return
+	}
+
+	log.Printf("자동 백업 완료: %s", autoBackup0)
+	notify(notifySuccess, "자동 백업 완료", targetMessage(target, filepath.Base(autoBackup0)))
+
+	// 자동 백업은 cleanupOldBackups 호출하지 않음 (항상 2개만 유지)
+}
+
+// createAutoBackup 자동 백업 파일을 순환시키고 새 백업을 _auto_0으로 생성
+func createAutoBackup(target saveTarget) (string, error) {
+	sourceFile := target.TargetFile
+	if err := checkSourceSave(target); err != nil {
+		return "", err
+	}
+
+	autoBackup0 := target.backupPath("auto_0")
+	autoBackup1 := target.backupPath("auto_1")
 
 	// 자동 백업 파일 순환 관리
 	if err := rotateAutoBackups(autoBackup0, autoBackup1); err != nil {
-		log.Printf("자동 백업 순환 실패: %v", err)
-		return
+		return "", fmt.Errorf("자동 백업 순환 실패: %v", err)
 	}
 
 	// 새로운 백업을 _auto_0으로 생성
 	if err := copyFile(sourceFile, autoBackup0); err != nil {
-		log.Printf("자동 백업 실패: %v", err)
+		return "", err
+	}
+
+	recordBackup(autoBackup0, target, triggerAuto, "")
+	return autoBackup0, nil
+}
+
+// pauseAutoBackup 지정한 시간 동안 자동 백업 중지. 시간이 지나면 자동으로 재개
+func pauseAutoBackup(d time.Duration) {
+	autoPauseMu.Lock()
+	if autoPauseTimer != nil {
+		autoPauseTimer.Stop()
+	}
+	autoPauseUntil = time.Now().Add(d)
+	autoPauseForGame = false
+	autoPauseTimer = time.AfterFunc(d, resumeAutoBackup)
+	log.Printf("자동 백업 일시 중지: %s까지", autoPauseUntil.Format("15:04:05"))
+	autoPauseMu.Unlock()
+
+	statusChanged()
+}
+
+// pauseAutoBackupUntilGameExit 게임이 종료될 때까지 자동 백업 중지
+func pauseAutoBackupUntilGameExit() {
+	if !isGameRunning() {
+		log.Println("게임이 실행 중이 아니므로 일시 중지하지 않습니다")
 		return
 	}
 
-	log.Printf("자동 백업 완료: %s", autoBackup0)
+	autoPauseMu.Lock()
+	if autoPauseTimer != nil {
+		autoPauseTimer.Stop()
+		autoPauseTimer = nil
+	}
+	autoPauseUntil = time.Time{}
+	autoPauseForGame = true
+	log.Println("자동 백업 일시 중지: 게임 종료까지")
+	autoPauseMu.Unlock()
 
-	// 자동 백업은 cleanupOldBackups 호출하지 않음 (항상 2개만 유지)
+	statusChanged()
+}
+
+// resumeAutoBackup 일시 중지 해제
+func resumeAutoBackup() {
+	autoPauseMu.Lock()
+	wasPaused := autoPauseForGame || !autoPauseUntil.IsZero()
+	if autoPauseTimer != nil {
+		autoPauseTimer.Stop()
+		autoPauseTimer = nil
+	}
+	autoPauseUntil = time.Time{}
+	autoPauseForGame = false
+	autoPauseMu.Unlock()
+
+	if wasPaused {
+		log.Println("자동 백업 재개")
+	}
+	statusChanged()
+}
+
+// resumeAutoBackupAfterGame 게임 종료까지 중지한 상태라면 재개
+func resumeAutoBackupAfterGame() {
+	autoPauseMu.Lock()
+	forGame := autoPauseForGame
+	autoPauseMu.Unlock()
+
+	if forGame {
+		resumeAutoBackup()
+	}
+}
+
+func isAutoBackupPaused() bool {
+	paused, _ := autoBackupPauseStatus()
+	return paused
+}
+
+// autoBackupPauseStatus 일시 중지 여부와 표시용 재개 조건 ("15:04:05까지", "게임 종료까지")
+func autoBackupPauseStatus() (bool, string) {
+	autoPauseMu.Lock()
+	defer autoPauseMu.Unlock()
+
+	switch {
+	case autoPauseForGame:
+		return true, "게임 종료까지"
+	case time.Now().Before(autoPauseUntil):
+		return true, autoPauseUntil.Format("15:04:05") + "까지"
+	default:
+		return false, ""
+	}
+}
+
+// backupInfo 백업 파일 하나와 카탈로그 기록
+type backupInfo struct {
+	Path    string
+	ModTime time.Time
+	Profile string
+	catalogEntry
+}
+
+// listBackups 모든 프로필, 계정의 백업 (자동/수동/퀵 슬롯 포함), 최근 것부터
+func listBackups() ([]backupInfo, error) {
+	var backups []backupInfo
+	var lastErr error
+	read := 0
+	seen := make(map[string]bool)
+	for _, target := range backupTargets() {
+		list, err := listTargetBackups(target)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		read++
+		for _, backup := range list {
+			if !seen[backup.Path] {
+				seen[backup.Path] = true
+				backups = append(backups, backup)
+			}
+		}
+	}
+	if read == 0 {
+		return nil, lastErr
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime.After(backups[j].ModTime)
+	})
+	return backups, nil
+}
+
+// listTargetBackups 세이브 하나의 백업, 최근 것부터
+func listTargetBackups(target saveTarget) ([]backupInfo, error) {
+	backupDir := target.BackupDir
+	entries, err := os.ReadDir(backupDir)
+	if err != nil {
+		return nil, fmt.Errorf("백업 디렉토리 읽기 실패: %v", err)
+	}
+
+	catalog := readCatalog(backupDir)
+	var backups []backupInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !target.isBackupName(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		backup := backupInfo{
+			Path:         filepath.Join(backupDir, entry.Name()),
+			ModTime:      info.ModTime(),
+			catalogEntry: catalog[entry.Name()],
+		}
+		if backup.Created.IsZero() {
+			backup.Created = info.ModTime()
+		}
+		if backup.Account == "" {
+			backup.Account = target.Account
+		}
+		backup.Profile = target.Profile.Name
+		backups = append(backups, backup)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime.After(backups[j].ModTime)
+	})
+	return backups, nil
+}
+
+// latestBackupPath 세이브 하나의 가장 최근 백업 파일 (자동/수동/퀵 슬롯 포함)
+// 복원 전 백업은 제외 (포함하면 최근 백업 복원을 다시 했을 때 방금 한 복원을 되돌리게 됨)
+func latestBackupPath(target saveTarget) (string, error) {
+	backups, err := listTargetBackups(target)
+	if err != nil {
+		return "", err
+	}
+	for _, backup := range backups {
+		if backup.Trigger != triggerPreRestore {
+			return backup.Path, nil
+		}
+	}
+	return "", fmt.Errorf("복원할 백업이 없습니다")
+}
+
+// deleteBackup 백업 파일과 카탈로그 기록 삭제
+func deleteBackup(backupPath string) error {
+	if err := os.Remove(backupPath); err != nil {
+		return fmt.Errorf("백업 파일 삭제 실패: %v", err)
+	}
+	forgetCatalogEntry(backupPath)
+	return nil
 }
 
 // rotateAutoBackups 자동 백업 파일 순환 관리
@@ -60,12 +281,14 @@ func rotateAutoBackups(autoBackup0, autoBackup1 string) error {
 		if err := os.Rename(autoBackup0, autoBackup1); err != nil {
 			return fmt.Errorf("auto_0을 auto_1로 이동 실패: %v", err)
 		}
+		renameCatalogEntry(autoBackup0, autoBackup1)
 		log.Printf("자동 백업 순환: auto_0 → auto_1")
 	} else if exists0 && !exists1 {
 		// auto_0만 있을 때: auto_0을 auto_1로 이동
 		if err := os.Rename(autoBackup0, autoBackup1); err != nil {
 			return fmt.Errorf("auto_0을 auto_1로 이동 실패: %v", err)
 		}
+		renameCatalogEntry(autoBackup0, autoBackup1)
 		log.Printf("자동 백업 순환: auto_0 → auto_1")
 	}
 	// exists1만 있거나 둘 다 없는 경우는 그대로 진행 (새로운 auto_0 생성)
@@ -74,26 +297,150 @@ func rotateAutoBackups(autoBackup0, autoBackup1 string) error {
 }
 
 func performManualBackup() {
-	sourceFile := GetConfig().TargetFile
-	if _, err := os.Stat(sourceFile); os.IsNotExist(err) {
-		log.Printf("백업할 파일이 존재하지 않습니다: %s", sourceFile)
-		return
+	backupAllTargets(triggerManual, "수동 백업")
+}
+
+// backupAllTargets 모든 프로필에서 감시 중인 세이브를 백업
+func backupAllTargets(trigger, description string) {
+	backupTargetList(watchTargets(), trigger, description)
+}
+
+// backupProfile 프로필 하나의 세이브(계정, 추가 세이브 포함)를 백업
+func backupProfile(p *Profile, trigger, description string) {
+	backupTargetList(p.targets(), trigger, description)
+}
+
+func backupTargetList(targets []saveTarget, trigger, description string) {
+	for _, target := range targets {
+		backupPath, err := createBackup(target, trigger, "")
+		if err != nil {
+			log.Printf("%s 실패: %v", description, targetMessage(target, err.Error()))
+			continue
+		}
+		log.Printf("%s 완료: %s", description, backupPath)
+	}
+}
+
+// createBackup 날짜_시간 형식의 누적 백업 생성 후 오래된 백업 정리 (복원 전 백업 제외)
+func createBackup(target saveTarget, trigger, label string) (backupPath string, err error) {
+	done := reportBusy()
+	defer func() {
+		done(err)
+		switch {
+		case err == nil:
+			notify(notifySuccess, "백업 완료", targetMessage(target, filepath.Base(backupPath)))
+		case !errors.Is(err, errSaveQuarantined):
+			notify(notifyFailure, "백업 실패", targetMessage(target, err.Error()))
+		}
+	}()
+
+	sourceFile := target.TargetFile
+	if err := checkSourceSave(target); err != nil {
+		return "", err
 	}
 
-	backupDir := GetConfig().BackupDir
-	now := time.Now()
-	backupFileName := fmt.Sprintf("StellarBladeSave00_%s.sav", now.Format("20060102_150405"))
-	backupPath := filepath.Join(backupDir, backupFileName)
+	backupPath = uniqueBackupPath(target.backupPath(time.Now().Format("20060102_150405")))
 
 	if err := copyFile(sourceFile, backupPath); err != nil {
-		log.Printf("수동 백업 실패: %v", err)
-		return
+		return "", err
 	}
 
-	log.Printf("수동 백업 완료: %s", backupPath)
+	recordBackup(backupPath, target, trigger, label)
 
 	// 오래된 백업 파일 정리
-	cleanupOldBackups()
+	// 복원 전 백업에서는 정리하지 않음 (복원할 백업이 가장 오래된 것이면 복사하기 전에 지워지므로). 다음 백업 때 정리됨
+	if trigger != triggerPreRestore {
+		cleanupOldBackups(target)
+	}
+
+	return backupPath, nil
+}
+
+// uniqueBackupPath 같은 초에 여러 백업이 생길 때 덮어쓰지 않도록 번호 붙이기
+func uniqueBackupPath(path string) string {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s_%d%s", base, i, ext)
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
+
+// restoreBackup 백업 파일로 세이브 파일을 되돌림 (계정별 폴더의 백업은 그 계정의 세이브로)
+// 어느 세이브의 백업인지 모르거나 게임이 세이브를 쓰는 중이면 거부하고, 덮어쓰기 전에 현재 세이브를 백업
+func restoreBackup(backupPath string) (err error) {
+	target, err := targetForBackup(backupPath)
+	if err != nil {
+		notify(notifyFailure, "복원 실패", err.Error())
+		return err
+	}
+	done := reportBusy()
+	defer func() {
+		done(err)
+		if err != nil {
+			notify(notifyFailure, "복원 실패", targetMessage(target, err.Error()))
+		} else {
+			notify(notifyRestore, "복원 완료", targetMessage(target, filepath.Base(backupPath)))
+		}
+	}()
+
+	targetFile := target.TargetFile
+	if isSaveBeingWritten(targetFile) {
+		return fmt.Errorf("게임이 세이브 파일을 쓰는 중입니다. 잠시 후 다시 시도하세요")
+	}
+
+	if _, err := os.Stat(targetFile); err == nil {
+		// 현재 세이브가 손상 의심으로 격리된 경우에는 그대로 복원 진행
+		snapshot, err := createBackup(target, triggerPreRestore, "")
+		switch {
+		case errors.Is(err, errSaveQuarantined):
+			log.Printf("현재 세이브는 격리되었습니다: %v", err)
+		case err != nil:
+			return fmt.Errorf("복원 전 현재 세이브 백업 실패: %v", err)
+		default:
+			log.Printf("복원 전 현재 세이브 백업: %s", snapshot)
+		}
+	}
+
+	// 임시 파일에 먼저 복사한 뒤 교체해서 중간에 실패해도 세이브가 깨지지 않도록 함
+	tempFile := targetFile + ".restore.tmp"
+	if err := copyFile(backupPath, tempFile); err != nil {
+		return err
+	}
+	if err := os.Rename(tempFile, targetFile); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("세이브 파일 교체 실패: %v", err)
+	}
+
+	return nil
+}
+
+// isSaveBeingWritten 최근 쓰기가 감지되었거나 파일 크기가 아직 변하는 중인지 확인
+func isSaveBeingWritten(targetFile string) bool {
+	if sinceLastTargetWrite(targetFile) < 3*time.Second {
+		return true
+	}
+
+	before, err := os.Stat(targetFile)
+	if err != nil {
+		return false
+	}
+	if time.Since(before.ModTime()) < 3*time.Second {
+		return true
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	after, err := os.Stat(targetFile)
+	if err != nil {
+		return false
+	}
+	return after.Size() != before.Size() || !after.ModTime().Equal(before.ModTime())
 }
 
 func copyFile(src, dst string) error {
@@ -122,26 +469,35 @@ func copyFile(src, dst string) error {
 	return nil
 }
 
-func cleanupOldBackups() {
-	maxBackups := GetConfig().MaxBackups
+// cleanupAllBackups 모든 세이브의 누적 백업을 max_backups에 맞게 정리 (설정을 바꿨을 때)
+func cleanupAllBackups() {
+	for _, target := range watchTargets() {
+		cleanupOldBackups(target)
+	}
+}
+
+// cleanupOldBackups 세이브 하나의 누적 백업이 프로필의 max_backups를 넘으면 오래된 것부터 삭제
+func cleanupOldBackups(target saveTarget) {
+	maxBackups := target.Profile.MaxBackups
 	if maxBackups <= 0 {
 		return
 	}
 
-	backupDir := GetConfig().BackupDir
+	backupDir := target.BackupDir
 	entries, err := os.ReadDir(backupDir)
 	if err != nil {
 		log.Printf("백업 디렉토리 읽기 실패: %v", err)
 		return
 	}
 
-	// 백업 파일만 필터링 (자동 백업 파일 제외)
+	// 백업 파일만 필터링 (자동 백업 파일, 고정된 백업 제외)
+	catalog := readCatalog(backupDir)
 	var backupFiles []os.DirEntry
 	for _, entry := range entries {
 		if !entry.IsDir() &&
-			strings.HasPrefix(entry.Name(), "StellarBladeSave00_") &&
-			strings.HasSuffix(entry.Name(), ".sav") &&
-			!strings.Contains(entry.Name(), "_auto_") { // 자동 백업 파일 제외
+			!catalog[entry.Name()].Pinned &&
+			target.isBackupName(entry.Name()) &&
+			!target.isRotatingName(entry.Name()) { // 자동 백업 파일, 퀵 슬롯 제외
 			backupFiles = append(backupFiles, entry)
 		}
 	}
@@ -163,6 +519,7 @@ func cleanupOldBackups() {
 		if err := os.Remove(filePath); err != nil {
 			log.Printf("오래된 백업 파일 삭제 실패: %v", err)
 		} else {
+			forgetCatalogEntry(filePath)
 			log.Printf("오래된 백업 파일 삭제: %s", backupFiles[i].Name())
 		}
 	}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// ageFile 방금 쓴 세이브를 게임이 쓰는 중으로 보지 않도록 수정 시각을 옛날로
+func ageFile(t *testing.T, path string) {
+	t.Helper()
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readSave(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestRestoreLatestTwice 최근 백업 복원을 두 번 해도 복원 전 백업으로 되돌아가지 않음
+func TestRestoreLatestTwice(t *testing.T) {
+	root := t.TempDir()
+	cfg := &Config{Profile: testProfile(root, "primary")}
+	useTestConfig(t, cfg)
+	p := &cfg.Profile
+	save := p.targetPath()
+
+	writeSave(t, save, "backed up")
+	if _, err := createBackup(p.primaryTarget(), triggerManual, ""); err != nil {
+		t.Fatal(err)
+	}
+	writeSave(t, save, "current")
+
+	for i := 1; i <= 2; i++ {
+		ageFile(t, save)
+		actionRestoreLatest(p)
+		if got := readSave(t, save); got != "backed up" {
+			t.Fatalf("after restore %d save = %q, want %q", i, got, "backed up")
+		}
+	}
+
+	// 복원 전 백업만 남아 있으면 복원할 백업이 없음
+	latest, err := latestBackupPath(p.primaryTarget())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := deleteBackup(latest); err != nil {
+		t.Fatal(err)
+	}
+	if path, err := latestBackupPath(p.primaryTarget()); err == nil {
+		t.Errorf("latestBackupPath = %s, want error when only pre-restore snapshots remain", path)
+	}
+}
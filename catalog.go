@@ -0,0 +1,245 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 백업 생성 원인
+const (
+	triggerAuto         = "auto"
+	triggerManual       = "manual"
+	triggerHotkey       = "hotkey"
+	triggerSessionStart = "session_start"
+	triggerSessionEnd   = "session_end"
+	triggerQuickSave    = "quick_save"
+	triggerPreRestore   = "pre_restore"
+	triggerBaseline     = "baseline"
+	triggerImport       = "import"
+)
+
+// catalogEntry 백업 파일 하나의 메타데이터
+type catalogEntry struct {
+	File    string    `json:"file"`
+	Created time.Time `json:"created"`
+	Trigger string    `json:"trigger"`
+	Session string    `json:"session,omitempty"`
+	Label   string    `json:"label,omitempty"`
+	Pinned  bool      `json:"pinned,omitempty"`
+	Account string    `json:"account,omitempty"`
+	Target  string    `json:"target,omitempty"` // 백업한 세이브 파일 (extra_targets도 같은 폴더를 쓰므로 구분용)
+	Source  string    `json:"source,omitempty"` // 다른 도구에서 가져온 백업의 원래 경로
+
+	// 백업 당시 세이브 파일 수정 시각 (되돌림 감지용)
+	SourceModTime time.Time `json:"source_mod_time,omitempty"`
+}
+
+var (
+	catalogMu        sync.Mutex
+	catalogListeners []func()
+)
+
+// onCatalogChange 카탈로그가 바뀔 때마다 호출할 함수 등록
+func onCatalogChange(listener func()) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	catalogListeners = append(catalogListeners, listener)
+}
+
+// catalogPath 백업 폴더마다 카탈로그 하나 (계정별 폴더도 각자 가짐)
+// 상태 폴더의 catalogs 아래 "<폴더 이름>-<경로 해시>.json"
+func catalogPath(backupDir string) (string, error) {
+	dir, err := filepath.Abs(backupDir)
+	if err != nil {
+		dir = filepath.Clean(backupDir)
+	}
+	key := dir
+	if runtime.GOOS == "windows" {
+		key = strings.ToLower(key)
+	}
+	sum := sha1.Sum([]byte(key))
+	name := fmt.Sprintf("%s-%s.json", safeFileName(filepath.Base(dir)), hex.EncodeToString(sum[:6]))
+	return stateFilePath(filepath.Join("catalogs", name))
+}
+
+// legacyCatalogPath 이전 버전이 백업 폴더 안에 두던 카탈로그 (처음 저장할 때 상태 폴더로 옮김)
+func legacyCatalogPath(backupDir string) string {
+	return filepath.Join(backupDir, "catalog.json")
+}
+
+// loadCatalog 백업 카탈로그 읽기 (파일이 없으면 이전 위치의 카탈로그, 그것도 없으면 빈 카탈로그)
+func loadCatalog(backupDir string) (map[string]catalogEntry, error) {
+	entries := make(map[string]catalogEntry)
+
+	path, err := catalogPath(backupDir)
+	if err != nil {
+		return nil, fmt.Errorf("카탈로그 경로 가져오기 실패: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		data, err = os.ReadFile(legacyCatalogPath(backupDir))
+	}
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("카탈로그 읽기 실패: %v", err)
+	}
+
+	var list []catalogEntry
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("카탈로그 파싱 실패: %v", err)
+	}
+	for _, entry := range list {
+		entries[entry.File] = entry
+	}
+
+	return entries, nil
+}
+
+func saveCatalog(backupDir string, entries map[string]catalogEntry) error {
+	list := make([]catalogEntry, 0, len(entries))
+	for _, entry := range entries {
+		list = append(list, entry)
+	}
+	sortCatalogEntries(list)
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("카탈로그 JSON 생성 실패: %v", err)
+	}
+
+	path, err := catalogPath(backupDir)
+	if err != nil {
+		return fmt.Errorf("카탈로그 경로 가져오기 실패: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("카탈로그 저장 실패: %v", err)
+	}
+
+	// 이전 위치의 카탈로그는 옮겼으므로 삭제
+	if err := os.Remove(legacyCatalogPath(backupDir)); err == nil {
+		log.Printf("카탈로그를 상태 폴더로 옮김: %s", path)
+	}
+
+	return nil
+}
+
+// updateCatalog 카탈로그를 읽어 수정한 뒤 다시 저장하고 변경을 알림
+func updateCatalog(backupDir string, modify func(entries map[string]catalogEntry)) {
+	catalogMu.Lock()
+	entries, err := loadCatalog(backupDir)
+	if err == nil {
+		modify(entries)
+		err = saveCatalog(backupDir, entries)
+	}
+	listeners := catalogListeners
+	catalogMu.Unlock()
+
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+// readCatalog 현재 카탈로그 읽기 (읽기 실패 시 빈 카탈로그)
+func readCatalog(backupDir string) map[string]catalogEntry {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	entries, err := loadCatalog(backupDir)
+	if err != nil {
+		log.Printf("%v", err)
+		return make(map[string]catalogEntry)
+	}
+	return entries
+}
+
+// setBackupPinned 고정된 백업은 오래된 백업 정리에서 제외됨
+func setBackupPinned(backupPath string, pinned bool) {
+	name := filepath.Base(backupPath)
+	updateCatalog(filepath.Dir(backupPath), func(entries map[string]catalogEntry) {
+		entry, ok := entries[name]
+		if !ok {
+			entry = catalogEntry{File: name, Created: fileModTime(backupPath)}
+		}
+		entry.Pinned = pinned
+		entries[name] = entry
+	})
+}
+
+// belongsTo 세이브 하나의 백업인지. 세이브를 기록하기 전의 항목은 파일 이름 규칙으로 판단
+func (e catalogEntry) belongsTo(target saveTarget) bool {
+	if e.Target != "" {
+		return filepath.Clean(e.Target) == filepath.Clean(target.TargetFile)
+	}
+	return target.isBackupName(e.File)
+}
+
+func fileModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// recordBackup 새 백업 파일을 현재 게임 세션, 원본 계정과 함께 기록
+func recordBackup(backupPath string, target saveTarget, trigger, label string) {
+	name := filepath.Base(backupPath)
+	updateCatalog(filepath.Dir(backupPath), func(entries map[string]catalogEntry) {
+		entries[name] = catalogEntry{
+			File:    name,
+			Created: time.Now(),
+			Trigger: trigger,
+			Session: currentGameSession(target.Profile.Name),
+			Label:   label,
+			Account: target.Account,
+			Target:  target.TargetFile,
+
+			SourceModTime: fileModTime(target.TargetFile),
+		}
+	})
+}
+
+// renameCatalogEntry 파일 이동(자동 백업 순환)에 맞춰 기록 이름 변경
+func renameCatalogEntry(oldPath, newPath string) {
+	oldName, newName := filepath.Base(oldPath), filepath.Base(newPath)
+	updateCatalog(filepath.Dir(newPath), func(entries map[string]catalogEntry) {
+		entry, ok := entries[oldName]
+		delete(entries, newName)
+		if !ok {
+			return
+		}
+		delete(entries, oldName)
+		entry.File = newName
+		entries[newName] = entry
+	})
+}
+
+func forgetCatalogEntry(backupPath string) {
+	name := filepath.Base(backupPath)
+	updateCatalog(filepath.Dir(backupPath), func(entries map[string]catalogEntry) {
+		delete(entries, name)
+	})
+}
+
+func sortCatalogEntries(list []catalogEntry) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].File < list[j].File
+	})
+}
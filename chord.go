@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 연속 입력 후속 키 대기 시간 기본값
+const defaultChordTimeout = 2 * time.Second
+
+// 연속 입력의 첫 키에 붙는 내부 동작 이름 접두사
+const chordLeaderPrefix = "chord:"
+
+var (
+	chordMu      sync.Mutex
+	chordTable   = make(map[string]map[string]string) // 첫 키 → 후속 키 → 동작
+	pendingChord []*hotkeyBinding
+	chordTimer   *time.Timer
+)
+
+// activeBindings 실제로 시스템에 등록할 단축키 → 동작
+// "ctrl+alt+b, 1" 같은 연속 입력은 첫 키 하나로 묶고 후속 키 목록은 chordTable에 보관
+func activeBindings(cfg *Config) map[string]string {
+	result := make(map[string]string)
+	chords := make(map[string]map[string]string)
+
+	for combo, action := range hotkeyBindings(cfg) {
+		steps := strings.Split(combo, ",")
+		if len(steps) == 1 {
+			result[combo] = action
+			continue
+		}
+
+		if err := validateChordStep(steps, action); err != nil {
+			setHotkeyError(combo, err)
+			log.Printf("단축키 등록 실패 (%s → %s): %v", combo, action, err)
+			continue
+		}
+		setHotkeyError(combo, nil)
+
+		leader, follow := strings.TrimSpace(steps[0]), strings.TrimSpace(steps[1])
+		if chords[leader] == nil {
+			chords[leader] = make(map[string]string)
+		}
+		chords[leader][follow] = action
+	}
+
+	for leader, follows := range chords {
+		if action, ok := result[leader]; ok {
+			log.Printf("%s 는 연속 입력의 첫 키로 사용되므로 %s 동작은 무시됩니다", leader, action)
+		}
+		result[leader] = chordLeaderAction(follows)
+	}
+
+	chordMu.Lock()
+	chordTable = chords
+	chordMu.Unlock()
+
+	return result
+}
+
+func validateChordStep(steps []string, action string) error {
+	if len(steps) != 2 {
+		return fmt.Errorf("연속 입력은 두 단계(첫 키, 후속 키)까지만 지원합니다")
+	}
+	for _, step := range steps {
+		if _, err := parseHotkeyCombo(step); err != nil {
+			return err
+		}
+	}
+	return validateAction(action)
+}
+
+// chordLeaderAction 첫 키에 등록할 내부 동작 이름
+// 후속 키 목록을 담아서 설정이 바뀌면 updateHotkeys가 다시 등록하도록 함
+func chordLeaderAction(follows map[string]string) string {
+	parts := make([]string, 0, len(follows))
+	for follow, action := range follows {
+		parts = append(parts, follow+"="+action)
+	}
+	sort.Strings(parts)
+	return chordLeaderPrefix + strings.Join(parts, "; ")
+}
+
+func isChordLeaderAction(action string) bool {
+	return strings.HasPrefix(action, chordLeaderPrefix)
+}
+
+func chordTimeout() time.Duration {
+	if ms := GetConfig().ChordTimeoutMs; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return defaultChordTimeout
+}
+
+// startChord 첫 키가 눌리면 후속 키를 제한 시간 동안만 등록
+func startChord(leader string) {
+	cancelChord()
+
+	chordMu.Lock()
+	follows := chordTable[leader]
+	chordMu.Unlock()
+
+	var bindings []*hotkeyBinding
+	for follow, action := range follows {
+		spec, err := parseHotkeyCombo(follow)
+		if err != nil {
+			continue
+		}
+
+		action := action
+		binding, err := newHotkeyBinding(spec, action, func() {
+			cancelChord()
+			runAction(action)
+		})
+		if err != nil {
+			log.Printf("연속 입력 후속 키 등록 실패 (%s, %s): %v", leader, follow, err)
+			continue
+		}
+		bindings = append(bindings, binding)
+	}
+
+	timeout := chordTimeout()
+	chordMu.Lock()
+	pendingChord = bindings
+	chordTimer = time.AfterFunc(timeout, func() {
+		log.Printf("연속 입력 시간 초과: %s", leader)
+		cancelChord()
+	})
+	chordMu.Unlock()
+
+	log.Printf("연속 입력 대기: %s (%v)", leader, timeout)
+}
+
+// cancelChord 대기 중인 후속 키를 모두 해제
+func cancelChord() {
+	chordMu.Lock()
+	bindings := pendingChord
+	pendingChord = nil
+	if chordTimer != nil {
+		chordTimer.Stop()
+		chordTimer = nil
+	}
+	chordMu.Unlock()
+
+	for _, binding := range bindings {
+		binding.release()
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const usageText = `사용법:
+  sb-backup-creator                 시스템 트레이에서 실행
+  sb-backup-creator --config <파일> [<명령>]
+                                    지정한 설정 파일 사용 (모든 명령 앞에 쓸 수 있음)
+  sb-backup-creator run [--profile <이름>] -- <명령>
+                                    게임 실행 전후 백업 (Steam 실행 옵션: sb-backup-creator run -- %command%)
+  sb-backup-creator hotkeys status  실행 중인 트레이 인스턴스의 단축키 등록 상태
+  sb-backup-creator manifest import [--dry-run] <manifest.yaml>
+                                    Ludusavi 매니페스트로 설치된 Steam 게임의 세이브를 찾아 프로필 추가
+  sb-backup-creator config validate [<파일>]
+                                    설정 파일 검사 (오류는 파일:줄:열 형식으로 출력)
+  sb-backup-creator config schema [<파일>]
+                                    settings.json의 JSON Schema 출력 (파일을 지정하면 저장)
+  sb-backup-creator export [--profile <이름>] <폴더>
+                                    백업을 Ludusavi 백업 형식(mapping.yaml)으로 내보내기
+  sb-backup-creator import [--profile <이름>] <폴더>
+                                    Ludusavi 백업을 이름이 같은 프로필의 백업으로 가져오기`
+
+// parseGlobalOptions 하위 명령 앞의 --config <파일> (--config=<파일>) 처리. 나머지 인자 반환
+func parseGlobalOptions(args []string) ([]string, error) {
+	for len(args) > 0 {
+		switch {
+		case args[0] == "--config":
+			if len(args) < 2 || args[1] == "" {
+				return nil, fmt.Errorf("--config 뒤에 설정 파일 경로가 필요합니다")
+			}
+			configFlag = args[1]
+			args = args[2:]
+		case strings.HasPrefix(args[0], "--config="):
+			configFlag = strings.TrimPrefix(args[0], "--config=")
+			if configFlag == "" {
+				return nil, fmt.Errorf("--config 뒤에 설정 파일 경로가 필요합니다")
+			}
+			args = args[1:]
+		default:
+			return args, nil
+		}
+	}
+	return args, nil
+}
+
+// runCommand 명령줄 하위 명령 처리
+// 하위 명령이 없으면 handled=false를 반환하고 트레이 모드로 실행
+func runCommand(args []string) (handled bool, exitCode int) {
+	if len(args) == 0 {
+		return false, 0
+	}
+
+	attachParentConsole()
+
+	switch args[0] {
+	case "run":
+		command := args[1:]
+		profile := ""
+		if len(command) > 1 && command[0] == "--profile" {
+			profile = command[1]
+			command = command[2:]
+		}
+		if len(command) > 0 && command[0] == "--" {
+			command = command[1:]
+		}
+		if len(command) == 0 {
+			fmt.Fprintf(os.Stderr, "%s\n", usageText)
+			return true, 2
+		}
+		return true, runLaunchWrapper(profile, command)
+	case "hotkeys":
+		if len(args) < 2 || args[1] != "status" {
+			fmt.Fprintf(os.Stderr, "%s\n", usageText)
+			return true, 2
+		}
+		return true, printHotkeyStatus()
+	case "manifest":
+		rest := args[1:]
+		if len(rest) < 2 || rest[0] != "import" {
+			fmt.Fprintf(os.Stderr, "%s\n", usageText)
+			return true, 2
+		}
+		dryRun := rest[1] == "--dry-run"
+		if dryRun {
+			rest = rest[1:]
+		}
+		if len(rest) != 2 {
+			fmt.Fprintf(os.Stderr, "%s\n", usageText)
+			return true, 2
+		}
+		return true, importManifest(rest[1], dryRun)
+	case "config":
+		if len(args) < 2 || (args[1] != "validate" && args[1] != "schema") || len(args) > 3 {
+			fmt.Fprintf(os.Stderr, "%s\n", usageText)
+			return true, 2
+		}
+		path := ""
+		if len(args) == 3 {
+			path = args[2]
+		}
+		if args[1] == "schema" {
+			return true, runConfigSchema(path)
+		}
+		return true, runConfigValidate(path)
+	case "export", "import":
+		rest := args[1:]
+		profile := ""
+		if len(rest) > 1 && rest[0] == "--profile" {
+			profile = rest[1]
+			rest = rest[2:]
+		}
+		if len(rest) != 1 {
+			fmt.Fprintf(os.Stderr, "%s\n", usageText)
+			return true, 2
+		}
+		if args[0] == "export" {
+			return true, runLudusaviExport(profile, rest[0])
+		}
+		return true, runLudusaviImport(profile, rest[0])
+	case "help", "-h", "--help":
+		fmt.Printf("%s\n", usageText)
+		return true, 0
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령입니다: %s\n%s\n", args[0], usageText)
+		return true, 2
+	}
+}
+
+// printHotkeyStatus 실행 중인 트레이 인스턴스에 단축키 상태를 물어서 출력
+func printHotkeyStatus() int {
+	var statuses []hotkeyStatus
+	if err := queryIPC("hotkeys status", &statuses); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if len(statuses) == 0 {
+		fmt.Println("설정된 단축키가 없습니다")
+		return 0
+	}
+
+	exitCode := 0
+	for _, status := range statuses {
+		fmt.Println(status)
+		if status.Error != "" {
+			exitCode = 1
+		}
+	}
+	return exitCode
+}
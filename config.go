@@ -1,37 +1,101 @@
 package main
 
 import (
+	"bytes"
 	"embed"
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 //go:embed settings.json
 var defaultSettings embed.FS
 
 type Config struct {
-	TargetFile  string `json:"target_file"`
-	BackupDir   string `json:"backup_dir"`
-	HotkeyCombo string `json:"hotkey_combo"`
-	AutoBackup  bool   `json:"auto_backup"`
-	MaxBackups  int    `json:"max_backups"`
+	Schema  string `json:"$schema,omitempty"` // 편집기 자동 완성용 JSON Schema (settings.schema.json)
+	Version int    `json:"version"`           // 설정 파일 버전 (migrate.go)
+
+	// 기본 프로필 (name, target_file, backup_dir 등은 설정 파일 최상위에 그대로 둠)
+	// Profile.Hotkeys는 아래 Hotkeys에 가려지므로 기본 프로필 단축키는 최상위 hotkeys 사용
+	Profile
+
+	HotkeyCombo          string              `json:"hotkey_combo"`
+	AutoBackup           bool                `json:"auto_backup"`
+	AutoBackupInGameOnly bool                `json:"auto_backup_in_game_only"`
+	QuickSaveHotkey      string              `json:"quick_save_hotkey"`
+	QuickLoadHotkey      string              `json:"quick_load_hotkey"`
+	QuickNextHotkey      string              `json:"quick_next_hotkey"`
+	QuickPrevHotkey      string              `json:"quick_prev_hotkey"`
+	QuickSlots           int                 `json:"quick_slots"`
+	Hotkeys              map[string]string   `json:"hotkeys"`
+	ChordTimeoutMs       int                 `json:"chord_timeout_ms"`
+	HotkeyFallbacks      map[string][]string `json:"hotkey_fallbacks"`
+	RecentBackups        int                 `json:"recent_backups"`
+	Notifications        NotificationConfig  `json:"notifications"`
+	Profiles             profileList         `json:"profiles"`
+
+	// 이 버전이 모르는 항목 (다른 버전에서 추가한 항목 등). 저장할 때 그대로 다시 씀
+	extra map[string]json.RawMessage
+}
+
+// profileList 추가 프로필 목록. 저장할 때 프로필마다 모르는 항목을 함께 씀
+type profileList []Profile
+
+// NotificationConfig 알림 종류별 사용 여부와 같은 종류 알림 사이 최소 간격
+type NotificationConfig struct {
+	Success            bool `json:"success"`
+	Failure            bool `json:"failure"`
+	Warning            bool `json:"warning"`
+	Quarantine         bool `json:"quarantine"`
+	Rollback           bool `json:"rollback"`
+	Restore            bool `json:"restore"`
+	MinIntervalSeconds int  `json:"min_interval_seconds"`
 }
 
 var (
-	config     *Config
 	configPath string
+	firstRun   bool // 이번 실행에서 설정 파일을 새로 만들었는지
+
+	// 현재 설정 스냅샷. 게시한 뒤에는 바꾸지 않고, 바꿀 때는 clone으로 복사해서 새로 게시
+	currentConfig atomic.Pointer[Config]
+
+	configListenersMu sync.Mutex
+	configListeners   []func(cfg, previous *Config)
 )
 
+// 설정 파일에 game_processes 항목이 없을 때 사용할 기본 게임 프로세스 이름
+var defaultGameProcesses = []string{"SB-Win64-Shipping.exe", "StellarBlade*.exe"}
+
+// 설정 파일에 notifications 항목이 없을 때의 기본값 (성공 알림만 끔)
+var defaultNotifications = NotificationConfig{
+	Failure:            true,
+	Warning:            true,
+	Quarantine:         true,
+	Rollback:           true,
+	Restore:            true,
+	MinIntervalSeconds: 30,
+}
+
 func initializeConfig() error {
 	// 설정 파일 경로 설정
-	exePath, err := os.Executable()
+	path, err := resolveConfigPath()
 	if err != nil {
-		return fmt.Errorf("실행 파일 경로 가져오기 실패: %v", err)
+		return err
+	}
+	configPath = path
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("설정 폴더 생성 실패: %v", err)
 	}
-	configPath = filepath.Join(filepath.Dir(exePath), "settings.json")
+
+	// 편집기 자동 완성용 JSON Schema (프로그램을 업데이트하면 새 항목을 반영)
+	writeConfigSchema()
 
 	// 설정 파일이 존재하는지 확인
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -39,6 +103,7 @@ func initializeConfig() error {
 		if err := createDefaultConfig(); err != nil {
 			return fmt.Errorf("기본 설정 파일 생성 실패: %v", err)
 		}
+		firstRun = true
 	}
 
 	// 설정 파일 로드
@@ -53,28 +118,26 @@ func createDefaultConfig() error {
 	}
 
 	// 기본 설정을 구조체로 파싱
-	var defaultConfig Config
-	if err := json.Unmarshal(defaultData, &defaultConfig); err != nil {
+	defaultConfig, err := decodeConfig(defaultData)
+	if err != nil {
 		return fmt.Errorf("기본 설정 파싱 실패: %v", err)
 	}
 
-	// 경로 변수 치환
-	defaultConfig.TargetFile = expandPath(defaultConfig.TargetFile)
-	defaultConfig.BackupDir = expandPath(defaultConfig.BackupDir)
-
-	// Steam ID 자동 감지 및 경로 수정
-	if strings.Contains(defaultConfig.TargetFile, "your_steam_id") {
-		steamID, err := detectSteamID()
-		if err != nil {
-			// Steam ID를 찾지 못하면 기본 경로 사용
-			defaultConfig.TargetFile = strings.Replace(defaultConfig.TargetFile, "\\your_steam_id", "", -1)
-		} else {
-			defaultConfig.TargetFile = strings.Replace(defaultConfig.TargetFile, "your_steam_id", steamID, -1)
-		}
+	// 찾은 세이브 중 가장 최근 것을 기본값으로 사용. 어느 것을 쓸지는 처음 실행 마법사에서 선택
+	// 찾지 못했고 기본 경로의 변수도 치환할 수 없으면 (Linux의 %localappdata% 등) 세이브 경로는 비워 두고 마법사에서 지정
+	if candidates := findSaveCandidates(); len(candidates) > 0 {
+		defaultConfig.TargetFile = candidates[0].TargetFile
+		defaultConfig.BackupDir = candidates[0].BackupDir
+	} else if err := defaultConfig.resolvePaths(); err != nil {
+		log.Printf("세이브 파일을 찾지 못했고 기본 경로를 쓸 수 없습니다. 처음 실행 마법사나 설정에서 경로를 지정하세요: %v", err)
+		defaultConfig.TargetFile = ""
+		defaultConfig.BackupDir = filepath.Join("{exe_dir}", "Backups")
+	} else {
+		log.Printf("세이브 파일을 찾지 못했습니다. 처음 실행 마법사나 설정에서 경로를 지정하세요: %s", defaultConfig.targetPath())
 	}
 
 	// 설정 파일로 저장
-	return saveConfig(&defaultConfig)
+	return saveConfig(defaultConfig)
 }
 
 func loadConfig() error {
@@ -83,79 +146,294 @@ func loadConfig() error {
 		return fmt.Errorf("설정 파일 읽기 실패: %v", err)
 	}
 
-	config = &Config{}
-	if err := json.Unmarshal(data, config); err != nil {
-		return fmt.Errorf("설정 파일 파싱 실패: %v", err)
+	// 예전 버전의 설정 파일이면 현재 버전으로 변환
+	data, err = migrateConfigFile(data)
+	if err != nil {
+		return fmt.Errorf("설정 파일 변환 실패: %v", err)
 	}
 
-	// 경로 변수 치환
-	config.TargetFile = expandPath(config.TargetFile)
-	config.BackupDir = expandPath(config.BackupDir)
+	// 파싱에 실패하면 기존 설정을 그대로 유지
+	loaded, err := decodeConfig(data)
+	if err != nil {
+		return fmt.Errorf("설정 파일 파싱 실패: %s:%v", filepath.Base(configPath), err)
+	}
+
+	// 경로 변수 치환 (설정 파일에는 치환 전 형태 유지)
+	if err := resolveProfilePaths(loaded); err != nil {
+		return err
+	}
 
+	rememberConfigData(data)
+	publishConfig(loaded)
 	return nil
 }
 
+// saveConfig 설정 파일 저장. 경로는 치환 전 형태 그대로 기록
 func saveConfig(cfg *Config) error {
+	if err := resolveProfilePaths(cfg); err != nil {
+		return err
+	}
+
 	// 백업 디렉토리 생성
-	if err := os.MkdirAll(cfg.BackupDir, 0755); err != nil {
+	if err := os.MkdirAll(cfg.backupFolder(), 0755); err != nil {
 		return fmt.Errorf("백업 디렉토리 생성 실패: %v", err)
 	}
 
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	cfg.Version = configVersion
+	data, err := encodeConfig(cfg)
 	if err != nil {
-		return fmt.Errorf("설정 JSON 생성 실패: %v", err)
+		return err
+	}
+
+	// 직접 고친 설정 파일이면 바뀐 값만 고쳐 써서 주석과 항목 순서 유지
+	if existing, err := os.ReadFile(configPath); err == nil {
+		if patched, err := patchJSONC(existing, data); err == nil {
+			data = patched
+		} else {
+			log.Printf("기존 설정 파일을 읽을 수 없어 새로 씁니다: %v", err)
+		}
 	}
 
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
 		return fmt.Errorf("설정 파일 저장 실패: %v", err)
 	}
+	rememberConfigData(data)
 
-	config = cfg
+	publishConfig(cfg)
 	return nil
 }
 
-func expandPath(path string) string {
-	if strings.HasPrefix(path, "%localappdata%") {
-		localAppData := os.Getenv("LOCALAPPDATA")
-		return strings.Replace(path, "%localappdata%", localAppData, 1)
+func isNumeric(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// resolveProfilePaths 모든 프로필의 경로 변수 치환. 치환할 수 없는 변수가 있으면 프로필별로 모아서 오류
+func resolveProfilePaths(cfg *Config) error {
+	var errs []string
+	for _, p := range profilesOf(cfg) {
+		if err := p.resolvePaths(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s 프로필 %v", p.Name, err))
+		}
 	}
-	return path
+	if len(errs) > 0 {
+		return fmt.Errorf("경로 변수 치환 실패: %s", strings.Join(errs, "; "))
+	}
+	return nil
 }
 
-func detectSteamID() (string, error) {
-	// Steam 설치 경로에서 사용자 ID 찾기
-	localAppData := os.Getenv("LOCALAPPDATA")
-	sbPath := filepath.Join(localAppData, "SB", "Saved", "SaveGames")
+// GetConfig 현재 설정 스냅샷
+// 다른 고루틴이 새 설정을 게시해도 받은 스냅샷은 바뀌지 않으므로, 한 작업 안에서는 한 번 받아서 계속 사용
+func GetConfig() *Config {
+	return currentConfig.Load()
+}
 
-	entries, err := os.ReadDir(sbPath)
-	if err != nil {
-		return "", err
+// onConfigChange 새 설정이 게시될 때마다 호출할 함수 등록
+func onConfigChange(listener func(cfg, previous *Config)) {
+	configListenersMu.Lock()
+	defer configListenersMu.Unlock()
+	configListeners = append(configListeners, listener)
+}
+
+// publishConfig 새 설정 스냅샷으로 바꾸고 등록된 함수에 알림 (처음 읽은 설정은 알리지 않음)
+func publishConfig(cfg *Config) {
+	previous := currentConfig.Swap(cfg)
+	if previous == nil {
+		return
+	}
+
+	configListenersMu.Lock()
+	listeners := configListeners
+	configListenersMu.Unlock()
+
+	for _, listener := range listeners {
+		listener(cfg, previous)
+	}
+}
+
+// clone 바꿔서 다시 게시할 수 있는 설정 복사본 (맵과 슬라이스까지 복사)
+func (c *Config) clone() *Config {
+	copied := *c
+	copied.Profile = c.Profile.clone()
+	copied.Hotkeys = cloneMap(c.Hotkeys)
+	// extra는 읽은 뒤 바꾸지 않으므로 복사본과 함께 써도 됨
+	if c.HotkeyFallbacks != nil {
+		copied.HotkeyFallbacks = make(map[string][]string, len(c.HotkeyFallbacks))
+		for combo, fallbacks := range c.HotkeyFallbacks {
+			copied.HotkeyFallbacks[combo] = append([]string(nil), fallbacks...)
+		}
+	}
+	if c.Profiles != nil {
+		copied.Profiles = make(profileList, len(c.Profiles))
+		for i, p := range c.Profiles {
+			copied.Profiles[i] = p.clone()
+		}
 	}
+	return &copied
+}
+
+// clone 프로필 복사본 (맵과 슬라이스까지 복사)
+func (p Profile) clone() Profile {
+	p.ExtraTargets = cloneStrings(p.ExtraTargets)
+	p.Validators = cloneStrings(p.Validators)
+	p.GameProcesses = cloneStrings(p.GameProcesses)
+	p.Hotkeys = cloneMap(p.Hotkeys)
+	p.extraTargets = cloneStrings(p.extraTargets)
+	return p
+}
+
+// cloneStrings nil은 nil로 유지 (omitempty, 기본값 판단에 nil 여부를 씀)
+func cloneStrings(list []string) []string {
+	if list == nil {
+		return nil
+	}
+	return append([]string{}, list...)
+}
+
+func cloneMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(m))
+	for key, value := range m {
+		copied[key] = value
+	}
+	return copied
+}
 
-	for _, entry := range entries {
-		if entry.IsDir() && entry.Name() != "." && entry.Name() != ".." {
-			// 숫자로만 구성된 디렉토리 찾기 (Steam ID)
-			if isNumeric(entry.Name()) {
-				savePath := filepath.Join(sbPath, entry.Name(), "StellarBladeSave00.sav")
-				if _, err := os.Stat(savePath); err == nil {
-					return entry.Name(), nil
-				}
+// 설정 파일에서 이 버전이 아는 항목 이름
+var (
+	configKeys  = jsonKeys(reflect.TypeOf(Config{}))
+	profileKeys = jsonKeys(reflect.TypeOf(Profile{}))
+)
+
+// jsonKeys 구조체의 JSON 항목 이름 (포함한 구조체의 항목 포함)
+func jsonKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for key := range jsonKeys(field.Type) {
+				keys[key] = true
 			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name := jsonFieldName(field); name != "" {
+			keys[name] = true
 		}
 	}
+	return keys
+}
 
-	return "", fmt.Errorf("Steam ID를 찾을 수 없습니다")
+// jsonFieldName 구조체 항목의 JSON 이름 (json:"-"이면 빈 문자열)
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
 }
 
-func isNumeric(s string) bool {
-	for _, c := range s {
-		if c < '0' || c > '9' {
-			return false
+// unknownFields JSON 객체에서 known에 없는 항목
+func unknownFields(data []byte, known map[string]bool) map[string]json.RawMessage {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(data, &object) != nil {
+		return nil
+	}
+	var extra map[string]json.RawMessage
+	for key, value := range object {
+		if known[key] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
 		}
+		extra[key] = value
 	}
-	return len(s) > 0
+	return extra
 }
 
-func GetConfig() *Config {
-	return config
+// appendFields 인코딩한 JSON 객체 끝에 항목 추가 (이름 순)
+func appendFields(object []byte, fields map[string]json.RawMessage) ([]byte, error) {
+	if len(fields) == 0 {
+		return object, nil
+	}
+
+	var b bytes.Buffer
+	b.Write(bytes.TrimSuffix(bytes.TrimSpace(object), []byte("}")))
+	empty := bytes.Equal(bytes.TrimSpace(object), []byte("{}"))
+	for i, key := range sortedRawKeys(fields) {
+		if i > 0 || !empty {
+			b.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		b.Write(name)
+		b.WriteByte(':')
+		if err := json.Compact(&b, fields[key]); err != nil {
+			return nil, fmt.Errorf("%s 항목을 쓸 수 없습니다: %v", key, err)
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func sortedRawKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MarshalJSON 프로필마다 모르는 항목을 함께 씀
+func (l profileList) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("null"), nil
+	}
+
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i, p := range l {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		data, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = appendFields(data, p.extra); err != nil {
+			return nil, err
+		}
+		b.Write(data)
+	}
+	b.WriteByte(']')
+	return b.Bytes(), nil
+}
+
+// encodeConfig 설정 파일 내용 (구조체 순서대로 쓰고 모르는 항목은 끝에 덧붙임)
+func encodeConfig(cfg *Config) ([]byte, error) {
+	data, err := json.Marshal(cfg)
+	if err == nil {
+		data, err = appendFields(data, cfg.extra)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("설정 JSON 생성 실패: %v", err)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return nil, fmt.Errorf("설정 JSON 생성 실패: %v", err)
+	}
+	return indented.Bytes(), nil
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// settings.json 변경 감시
+// 편집기로 고친 설정을 재시작 없이 적용. 새 내용을 검사해서 문제가 없을 때만 바꾸고,
+// 문제가 있으면 기존 설정으로 계속 실행하면서 알림
+
+// 편집기는 저장할 때 여러 번 쓰거나 임시 파일을 이름 바꿔 덮어쓰므로 마지막 이벤트 후 잠시 기다렸다 읽음
+const configReloadDelay = 500 * time.Millisecond
+
+var (
+	configWatcher     *fsnotify.Watcher
+	configWatcherDone chan bool
+
+	configDataMu sync.Mutex
+	configData   []byte // 마지막으로 읽거나 저장한 설정 파일 내용 (프로그램이 직접 저장한 것은 다시 읽지 않음)
+)
+
+// rememberConfigData 설정 파일 내용 기록
+func rememberConfigData(data []byte) {
+	configDataMu.Lock()
+	configData = data
+	configDataMu.Unlock()
+}
+
+// configDataChanged 설정 파일 내용이 마지막으로 읽거나 저장한 것과 다른지. 다르면 새 내용으로 기록
+func configDataChanged(data []byte) bool {
+	configDataMu.Lock()
+	defer configDataMu.Unlock()
+	if bytes.Equal(data, configData) {
+		return false
+	}
+	configData = data
+	return true
+}
+
+// startConfigWatcher 설정 파일이 있는 폴더 감시 시작 (이름 바꿔 덮어쓰는 편집기도 감지하도록 파일이 아니라 폴더를 감시)
+func startConfigWatcher() {
+	var err error
+	configWatcher, err = fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("설정 파일 감시자 생성 실패: %v", err)
+		return
+	}
+	if err := configWatcher.Add(filepath.Dir(configPath)); err != nil {
+		log.Printf("설정 파일 감시 추가 실패: %v", err)
+		configWatcher.Close()
+		return
+	}
+	configWatcherDone = make(chan bool)
+	log.Printf("설정 파일 감시 시작: %s", configPath)
+
+	go func(w *fsnotify.Watcher, done chan bool) {
+		defer w.Close()
+
+		var reload <-chan time.Time
+		for {
+			select {
+			case event, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(configPath) ||
+					event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				reload = time.After(configReloadDelay)
+
+			case <-reload:
+				reload = nil
+				reloadChangedConfig()
+
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("설정 파일 감시 오류: %v", err)
+
+			case <-done:
+				return
+			}
+		}
+	}(configWatcher, configWatcherDone)
+}
+
+func stopConfigWatcher() {
+	if configWatcherDone != nil {
+		close(configWatcherDone)
+		configWatcherDone = nil
+	}
+}
+
+// reloadChangedConfig 바뀐 설정 파일을 검사해서 적용
+// 문제가 있으면 기존 설정을 그대로 두고 줄:열이 있는 오류를 기록하고 알림
+func reloadChangedConfig() {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		// 덮어쓰는 중이라 잠시 없을 수 있음. 다시 만들어지면 Create 이벤트로 다시 읽음
+		log.Printf("설정 파일 읽기 실패: %v", err)
+		return
+	}
+	if !configDataChanged(data) {
+		return
+	}
+
+	// 예전 버전의 내용을 붙여 넣었으면 파일을 변환해 두고 변환한 내용으로 적용
+	if migrated, err := migrateConfigFile(data); err == nil {
+		data = migrated
+	}
+
+	// 검사하면서 경로 변수도 치환됨
+	cfg, errs := validateConfigData(data)
+	if cfg == nil || len(errs) > 0 {
+		for _, err := range errs {
+			log.Printf("설정 파일 오류: %v", err)
+		}
+		message := fmt.Sprintf("%v\n기존 설정으로 계속 실행합니다", errs[0])
+		if len(errs) > 1 {
+			message = fmt.Sprintf("%v 외 %d개\n기존 설정으로 계속 실행합니다", errs[0], len(errs)-1)
+		}
+		notify(notifyFailure, "설정 파일 오류", message)
+		return
+	}
+
+	log.Println("바뀐 설정 파일 적용")
+	publishConfig(cfg)
+}
@@ -0,0 +1,6 @@
+//go:build !windows
+
+package main
+
+// attachParentConsole Windows 외에는 콘솔 연결이 필요 없음
+func attachParentConsole() {}
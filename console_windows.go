@@ -0,0 +1,33 @@
+//go:build windows
+
+package main
+
+import (
+	"log"
+	"os"
+)
+
+var procAttachConsole = kernel32.NewProc("AttachConsole")
+
+const ATTACH_PARENT_PROCESS = 0xFFFFFFFF
+
+// attachParentConsole -H windowsgui로 빌드해도 명령줄 하위 명령의 출력이 보이도록
+// 실행한 콘솔(cmd, PowerShell)에 연결
+func attachParentConsole() {
+	// 출력이 파일이나 파이프로 리다이렉트된 경우는 그대로 사용
+	if _, err := os.Stdout.Stat(); err == nil {
+		return
+	}
+
+	if ret, _, _ := procAttachConsole.Call(ATTACH_PARENT_PROCESS); ret == 0 {
+		return
+	}
+
+	conout, err := os.OpenFile("CONOUT$", os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	os.Stdout = conout
+	os.Stderr = conout
+	log.SetOutput(conout)
+}
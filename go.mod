@@ -5,9 +5,11 @@ go 1.24.2
 require (
 	github.com/fsnotify/fsnotify v1.9.0
 	github.com/getlantern/systray v1.2.2
+	github.com/godbus/dbus/v5 v5.1.0
 	github.com/sqweek/dialog v0.0.0-20240226140203-065105509627
 	golang.design/x/hotkey v0.4.1
 	golang.org/x/sys v0.13.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
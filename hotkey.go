@@ -1,219 +1,472 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 
 	"golang.design/x/hotkey"
 	"golang.design/x/hotkey/mainthread"
 )
 
+// hotkeyBinding 등록된 단축키 하나와 연결된 동작
+type hotkeyBinding struct {
+	hk         *hotkey.Hotkey
+	action     string
+	registered string   // 실제로 등록된 조합 (대체 조합일 수 있음)
+	fallbacks  []string // 등록할 때의 대체 조합 목록 (바뀌면 다시 등록)
+	stop       chan bool
+}
+
+// hotkeyStatus 설정된 단축키 하나의 등록 상태
+type hotkeyStatus struct {
+	Combo      string `json:"combo"`
+	Action     string `json:"action"`
+	Registered string `json:"registered,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
 var (
-	hotkeyRegistered bool
-	currentHotkey    *hotkey.Hotkey
+	hotkeyMu      sync.Mutex
+	activeHotkeys = make(map[string]*hotkeyBinding)
+	hotkeyErrors  = make(map[string]error) // 등록에 실패한 조합별 오류
 )
 
+// hotkeyBindings 설정의 단축키 조합 → 동작 목록
+// 이전 버전의 단일 항목(hotkey_combo, quick_*_hotkey)과 프로필별 단축키("[프로필] 동작")도 함께 반영
+func hotkeyBindings(cfg *Config) map[string]string {
+	bindings := defaultProfileBindings(cfg)
+	for _, p := range cfg.Profiles {
+		for combo, action := range p.Hotkeys {
+			bindings[canonicalCombo(combo)] = profileAction(p.Name, action)
+		}
+	}
+	return bindings
+}
+
+// defaultProfileBindings 기본 프로필 대상 단축키 (최상위 hotkeys와 이전 버전 항목)
+func defaultProfileBindings(cfg *Config) map[string]string {
+	bindings := make(map[string]string)
+
+	legacy := []struct{ combo, action string }{
+		{cfg.HotkeyCombo, "backup"},
+		{cfg.QuickSaveHotkey, "quick_save"},
+		{cfg.QuickLoadHotkey, "quick_load"},
+		{cfg.QuickNextHotkey, "quick_next"},
+		{cfg.QuickPrevHotkey, "quick_prev"},
+	}
+	for _, binding := range legacy {
+		if binding.combo != "" {
+			bindings[canonicalCombo(binding.combo)] = binding.action
+		}
+	}
+
+	for combo, action := range cfg.Hotkeys {
+		bindings[canonicalCombo(combo)] = action
+	}
+
+	return bindings
+}
+
 func registerHotkeys() {
-	config := GetConfig()
-	if config.HotkeyCombo == "" {
+	bindings := activeBindings(GetConfig())
+	if len(bindings) == 0 {
 		log.Println("단축키가 설정되지 않았습니다")
 		return
 	}
 
-	log.Printf("단축키 등록: %s", config.HotkeyCombo)
+	for combo, action := range bindings {
+		registerBinding(combo, action)
+	}
+}
 
-	// 단축키 조합 파싱
-	modifiers, key := parseHotkeyCombo(config.HotkeyCombo)
-	if key == hotkey.Key0 {
-		log.Println("잘못된 단축키 형식입니다")
-		return
+// registerBinding 단축키 하나를 등록하고 눌릴 때마다 동작 실행
+// 실패하면 조합별로 오류를 기록해서 다른 단축키 등록에 영향을 주지 않음
+func registerBinding(combo, action string) error {
+	err := tryRegisterBinding(combo, action)
+	setHotkeyError(combo, err)
+
+	if err != nil {
+		log.Printf("단축키 등록 실패 (%s → %s): %v", combo, action, err)
+		notify(notifyFailure, "단축키 등록 실패", fmt.Sprintf("%s (%s): %v", combo, action, err))
+	}
+	refreshHotkeyMenu()
+	return err
+}
+
+// setHotkeyError 조합별 등록 오류 기록 (err가 nil이면 지움)
+func setHotkeyError(combo string, err error) {
+	hotkeyMu.Lock()
+	defer hotkeyMu.Unlock()
+
+	if err != nil {
+		hotkeyErrors[combo] = err
+	} else {
+		delete(hotkeyErrors, combo)
+	}
+}
+
+func tryRegisterBinding(combo, action string) error {
+	// 연속 입력의 첫 키는 동작 대신 후속 키 대기를 시작
+	handler := func() { runAction(action) }
+	if isChordLeaderAction(action) {
+		handler = func() { startChord(combo) }
+	} else if err := validateAction(action); err != nil {
+		return err
+	}
+
+	log.Printf("단축키 등록: %s → %s", combo, action)
+
+	// 설정된 조합이 다른 프로그램과 충돌하면 대체 조합을 순서대로 시도
+	fallbacks := hotkeyFallbacks(GetConfig())[combo]
+	candidates := append([]string{combo}, fallbacks...)
+	var failures []string
+	for _, candidate := range candidates {
+		// 단축키 조합 파싱
+		spec, err := parseHotkeyCombo(candidate)
+		if err == nil {
+			var binding *hotkeyBinding
+			binding, err = newHotkeyBinding(spec, action, handler)
+			if err == nil {
+				binding.registered = spec.String()
+				binding.fallbacks = fallbacks
+				hotkeyMu.Lock()
+				activeHotkeys[combo] = binding
+				hotkeyMu.Unlock()
+
+				log.Printf("단축키 등록 성공: %s → %s", spec, action)
+				if binding.registered != combo {
+					notify(notifyWarning, "단축키 대체 등록", fmt.Sprintf("%s 대신 %s 로 등록되었습니다 (%s)", combo, binding.registered, action))
+				}
+				return nil
+			}
+		}
+
+		if len(candidates) == 1 {
+			return err
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", canonicalCombo(candidate), err))
 	}
 
+	return fmt.Errorf("모든 조합 등록 실패 (%s)", strings.Join(failures, "; "))
+}
+
+// hotkeyFallbacks 설정된 조합(정규화) → 대체 조합 목록
+func hotkeyFallbacks(cfg *Config) map[string][]string {
+	fallbacks := make(map[string][]string)
+	for combo, list := range cfg.HotkeyFallbacks {
+		fallbacks[canonicalCombo(combo)] = list
+	}
+	return fallbacks
+}
+
+// newHotkeyBinding 시스템에 단축키를 등록하고 키가 눌릴 때마다 onKeydown 실행
+func newHotkeyBinding(spec hotkeySpec, action string, onKeydown func()) (*hotkeyBinding, error) {
+	var binding *hotkeyBinding
+	var err error
+
 	// mainthread에서 실행
 	mainthread.Call(func() {
-		// 기존 단축키 해제
-		if currentHotkey != nil {
-			currentHotkey.Unregister()
+		hk := hotkey.New(spec.Modifiers(), spec.Key())
+		if err = hk.Register(); err != nil {
+			return
 		}
+		binding = &hotkeyBinding{hk: hk, action: action, stop: make(chan bool)}
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		// 새 단축키 등록
-		hk := hotkey.New(modifiers, key)
-		err := hk.Register()
-		if err != nil {
-			log.Printf("단축키 등록 실패: %v", err)
-			return
+	// 고루틴에서 키 이벤트 대기
+	go func() {
+		for {
+			select {
+			case <-binding.hk.Keydown():
+				log.Printf("단축키 감지: %s → %s", spec, action)
+				go onKeydown()
+			case <-binding.stop:
+				return
+			}
 		}
+	}()
 
-		currentHotkey = hk
-		hotkeyRegistered = true
-		log.Printf("단축키 등록 성공: %v", hk)
+	return binding, nil
+}
 
-		// 고루틴에서 키 이벤트 대기
-		go func() {
-			for {
-				<-hk.Keydown()
-				log.Println("백업 단축키 감지")
-				go performManualBackup() // 수동 백업과 동일한 로직 사용
-			}
-		}()
+// release 이벤트 대기를 멈추고 시스템 단축키 해제
+func (b *hotkeyBinding) release() {
+	close(b.stop)
+	mainthread.Call(func() {
+		b.hk.Unregister()
 	})
 }
 
+// unregisterBinding 단축키 하나만 해제
+func unregisterBinding(combo string) {
+	hotkeyMu.Lock()
+	binding, ok := activeHotkeys[combo]
+	delete(activeHotkeys, combo)
+	hotkeyMu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	binding.release()
+	log.Printf("단축키 해제: %s", combo)
+	refreshHotkeyMenu()
+}
+
 func unregisterHotkeys() {
-	if hotkeyRegistered && currentHotkey != nil {
-		mainthread.Call(func() {
-			currentHotkey.Unregister()
-			currentHotkey = nil
-			hotkeyRegistered = false
-		})
-	}
-}
-
-func parseHotkeyCombo(combo string) ([]hotkey.Modifier, hotkey.Key) {
-	// "ctrl+shift+b" -> modifiers: [ModCtrl, ModShift], key: KeyB
-	parts := strings.Split(strings.ToLower(combo), "+")
-	var modifiers []hotkey.Modifier
-	var key hotkey.Key = hotkey.Key0
-
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		switch part {
-		case "ctrl", "control":
-			modifiers = append(modifiers, hotkey.ModCtrl)
-		case "shift":
-			modifiers = append(modifiers, hotkey.ModShift)
-		case "alt":
-			modifiers = append(modifiers, hotkey.ModAlt)
-		case "win", "windows", "cmd":
-			modifiers = append(modifiers, hotkey.ModWin)
-		default:
-			// 일반 키 매핑
-			key = mapStringToKey(part)
-		}
-	}
-
-	return modifiers, key
-}
-
-func mapStringToKey(s string) hotkey.Key {
-	// 알파벳
-	switch s {
-	case "a":
-		return hotkey.KeyA
-	case "b":
-		return hotkey.KeyB
-	case "c":
-		return hotkey.KeyC
-	case "d":
-		return hotkey.KeyD
-	case "e":
-		return hotkey.KeyE
-	case "f":
-		return hotkey.KeyF
-	case "g":
-		return hotkey.KeyG
-	case "h":
-		return hotkey.KeyH
-	case "i":
-		return hotkey.KeyI
-	case "j":
-		return hotkey.KeyJ
-	case "k":
-		return hotkey.KeyK
-	case "l":
-		return hotkey.KeyL
-	case "m":
-		return hotkey.KeyM
-	case "n":
-		return hotkey.KeyN
-	case "o":
-		return hotkey.KeyO
-	case "p":
-		return hotkey.KeyP
-	case "q":
-		return hotkey.KeyQ
-	case "r":
-		return hotkey.KeyR
-	case "s":
-		return hotkey.KeyS
-	case "t":
-		return hotkey.KeyT
-	case "u":
-		return hotkey.KeyU
-	case "v":
-		return hotkey.KeyV
-	case "w":
-		return hotkey.KeyW
-	case "x":
-		return hotkey.KeyX
-	case "y":
-		return hotkey.KeyY
-	case "z":
-		return hotkey.KeyZ
-	// 숫자
-	case "0":
-		return hotkey.Key0
-	case "1":
-		return hotkey.Key1
-	case "2":
-		return hotkey.Key2
-	case "3":
-		return hotkey.Key3
-	case "4":
-		return hotkey.Key4
-	case "5":
-		return hotkey.Key5
-	case "6":
-		return hotkey.Key6
-	case "7":
-		return hotkey.Key7
-	case "8":
-		return hotkey.Key8
-	case "9":
-		return hotkey.Key9
-	// 펑션키
-	case "f1":
-		return hotkey.KeyF1
-	case "f2":
-		return hotkey.KeyF2
-	case "f3":
-		return hotkey.KeyF3
-	case "f4":
-		return hotkey.KeyF4
-	case "f5":
-		return hotkey.KeyF5
-	case "f6":
-		return hotkey.KeyF6
-	case "f7":
-		return hotkey.KeyF7
-	case "f8":
-		return hotkey.KeyF8
-	case "f9":
-		return hotkey.KeyF9
-	case "f10":
-		return hotkey.KeyF10
-	case "f11":
-		return hotkey.KeyF11
-	case "f12":
-		return hotkey.KeyF12
-	// 기타
-	case "space":
-		return hotkey.KeySpace
-	case "enter":
-		return hotkey.KeyReturn
-	case "tab":
-		return hotkey.KeyTab
-	case "esc", "escape":
-		return hotkey.KeyEscape
+	cancelChord()
+
+	hotkeyMu.Lock()
+	combos := make([]string, 0, len(activeHotkeys))
+	for combo := range activeHotkeys {
+		combos = append(combos, combo)
+	}
+	hotkeyMu.Unlock()
+
+	for _, combo := range combos {
+		unregisterBinding(combo)
+	}
+}
+
+// 단축키 조합 파싱 오류 종류
+var (
+	errEmptyToken        = errors.New("빈 항목이 있습니다")
+	errUnknownKey        = errors.New("알 수 없는 키입니다")
+	errDuplicateModifier = errors.New("수정자 키가 중복되었습니다")
+	errMissingKey        = errors.New("일반 키가 없습니다")
+	errMultipleKeys      = errors.New("일반 키는 하나만 지정할 수 있습니다")
+)
+
+// hotkeyParseError 단축키 조합의 어느 항목이 잘못되었는지 알려주는 오류
+type hotkeyParseError struct {
+	Combo string
+	Token string
+	Err   error
+}
+
+func (e *hotkeyParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("단축키 %q: %v", e.Combo, e.Err)
+	}
+	return fmt.Sprintf("단축키 %q: %q %v", e.Combo, e.Token, e.Err)
+}
+
+func (e *hotkeyParseError) Unwrap() error {
+	return e.Err
+}
+
+// keyDef 설정에서 쓸 수 있는 키 하나 (표시 이름, 입력 이름들, 키 코드)
+type keyDef struct {
+	display string
+	names   []string
+	key     hotkey.Key
+}
+
+// modifierDef 수정자 키 하나. 표시 순서는 modifierTable 순서를 따름
+type modifierDef struct {
+	display  string
+	names    []string
+	modifier hotkey.Modifier
+}
+
+// keysByName 입력 이름(소문자) → 키 정의
+var keysByName = func() map[string]keyDef {
+	table := make(map[string]keyDef)
+	for _, def := range keyTable {
+		for _, name := range def.names {
+			table[name] = def
+		}
+	}
+	return table
+}()
+
+// hotkeySpec 파싱된 단축키 조합
+type hotkeySpec struct {
+	modifiers []modifierDef
+	key       keyDef
+}
+
+func (s hotkeySpec) Modifiers() []hotkey.Modifier {
+	modifiers := make([]hotkey.Modifier, len(s.modifiers))
+	for i, def := range s.modifiers {
+		modifiers[i] = def.modifier
+	}
+	return modifiers
+}
+
+func (s hotkeySpec) Key() hotkey.Key {
+	return s.key.key
+}
+
+// String 정규화된 표시 형식 (예: "Ctrl+Alt+Shift+F9")
+func (s hotkeySpec) String() string {
+	parts := make([]string, 0, len(s.modifiers)+1)
+	for _, def := range modifierTable {
+		for _, used := range s.modifiers {
+			if used.display == def.display {
+				parts = append(parts, def.display)
+			}
+		}
+	}
+	return strings.Join(append(parts, s.key.display), "+")
+}
+
+// parseHotkeyCombo "ctrl+shift+b" 형식의 단축키 조합 파싱
+func parseHotkeyCombo(combo string) (hotkeySpec, error) {
+	var spec hotkeySpec
+	hasKey := false
+
+	for _, part := range strings.Split(combo, "+") {
+		token := strings.ToLower(strings.TrimSpace(part))
+		if token == "" {
+			return spec, &hotkeyParseError{Combo: combo, Err: errEmptyToken}
+		}
+
+		if def, ok := lookupModifier(token); ok {
+			for _, used := range spec.modifiers {
+				if used.display == def.display {
+					return spec, &hotkeyParseError{Combo: combo, Token: part, Err: errDuplicateModifier}
+				}
+			}
+			spec.modifiers = append(spec.modifiers, def)
+			continue
+		}
+
+		def, ok := keysByName[token]
+		if !ok {
+			return spec, &hotkeyParseError{Combo: combo, Token: part, Err: errUnknownKey}
+		}
+		if hasKey {
+			return spec, &hotkeyParseError{Combo: combo, Token: part, Err: errMultipleKeys}
+		}
+		spec.key = def
+		hasKey = true
+	}
+
+	if !hasKey {
+		return spec, &hotkeyParseError{Combo: combo, Err: errMissingKey}
+	}
+
+	return spec, nil
+}
+
+func lookupModifier(token string) (modifierDef, bool) {
+	for _, def := range modifierTable {
+		for _, name := range def.names {
+			if name == token {
+				return def, true
+			}
+		}
+	}
+	return modifierDef{}, false
+}
+
+// canonicalCombo 표시/비교용 정규화 형식. 파싱할 수 없으면 입력을 그대로 반환
+// 연속 입력("ctrl+alt+b, 1")은 단계별로 정규화
+func canonicalCombo(combo string) string {
+	steps := strings.Split(combo, ",")
+	for i, step := range steps {
+		spec, err := parseHotkeyCombo(step)
+		if err != nil {
+			steps[i] = strings.TrimSpace(step)
+		} else {
+			steps[i] = spec.String()
+		}
+	}
+	return strings.Join(steps, ", ")
+}
+
+// hotkeyStatusList 설정된 단축키별 등록 상태 (정규화된 단축키 순)
+func hotkeyStatusList() []hotkeyStatus {
+	bindings := hotkeyBindings(GetConfig())
+	combos := make([]string, 0, len(bindings))
+	for combo := range bindings {
+		combos = append(combos, combo)
+	}
+	sort.Strings(combos)
+
+	hotkeyMu.Lock()
+	defer hotkeyMu.Unlock()
+
+	list := make([]hotkeyStatus, len(combos))
+	for i, combo := range combos {
+		status := hotkeyStatus{Combo: combo, Action: bindings[combo]}
+
+		// 연속 입력은 첫 키의 등록 상태를 따름
+		lookup, rest := combo, ""
+		if leader, follow, ok := strings.Cut(combo, ","); ok {
+			lookup, rest = strings.TrimSpace(leader), ","+follow
+		}
+
+		if err, ok := hotkeyErrors[combo]; ok {
+			status.Error = err.Error()
+		} else if binding, ok := activeHotkeys[lookup]; ok {
+			status.Registered = binding.registered + rest
+		} else if err, ok := hotkeyErrors[lookup]; ok {
+			status.Error = err.Error()
+		}
+		list[i] = status
+	}
+	return list
+}
+
+// String 트레이 메뉴/명령줄 표시용 한 줄 요약
+func (s hotkeyStatus) String() string {
+	switch {
+	case s.Error != "":
+		return fmt.Sprintf("%s → %s: 실패 (%s)", s.Combo, s.Action, s.Error)
+	case s.Registered == "":
+		return fmt.Sprintf("%s → %s: 등록 안 됨", s.Combo, s.Action)
+	case s.Registered != s.Combo:
+		return fmt.Sprintf("%s → %s: %s 로 대체 등록", s.Combo, s.Action, s.Registered)
 	default:
-		return hotkey.Key0 // 잘못된 키
+		return fmt.Sprintf("%s → %s", s.Registered, s.Action)
 	}
 }
 
+// updateHotkeys 바뀐 단축키만 해제/등록
 func updateHotkeys() {
+	cancelChord()
+	cfg := GetConfig()
+	configured := hotkeyBindings(cfg)
+	bindings := activeBindings(cfg)
+	fallbacks := hotkeyFallbacks(cfg)
+
+	hotkeyMu.Lock()
+	var removed []string
+	for combo, binding := range activeHotkeys {
+		// 동작이나 대체 조합 목록이 바뀐 단축키는 해제 후 다시 등록
+		if bindings[combo] != binding.action || !slices.Equal(binding.fallbacks, fallbacks[combo]) {
+			removed = append(removed, combo)
+		}
+	}
+	for combo := range hotkeyErrors {
+		if _, ok := configured[combo]; !ok {
+			delete(hotkeyErrors, combo)
+		}
+	}
+	hotkeyMu.Unlock()
+
 	// 기존 단축키 해제
-	unregisterHotkeys()
+	for _, combo := range removed {
+		unregisterBinding(combo)
+	}
 
 	// 새로운 단축키 등록
-	registerHotkeys()
+	for combo, action := range bindings {
+		hotkeyMu.Lock()
+		_, active := activeHotkeys[combo]
+		hotkeyMu.Unlock()
+		if !active {
+			registerBinding(combo, action)
+		}
+	}
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"golang.design/x/hotkey"
+)
+
+// modifierTable 수정자 키 (X11)
+var modifierTable = []modifierDef{
+	{"Ctrl", []string{"ctrl", "control"}, hotkey.ModCtrl},
+	{"Alt", []string{"alt"}, hotkey.Mod1},
+	{"Shift", []string{"shift"}, hotkey.ModShift},
+	{"Win", []string{"win", "windows", "cmd", "super", "meta"}, hotkey.Mod4},
+}
+
+// keyTable 일반 키 (X11 keysym)
+// 미디어 키(XF86 keysym)는 16비트 키 코드에 들어가지 않아 지원하지 않음
+var keyTable = func() []keyDef {
+	var table []keyDef
+
+	// 알파벳, 숫자
+	for c := 'a'; c <= 'z'; c++ {
+		table = append(table, keyDef{string(c - 'a' + 'A'), []string{string(c)}, hotkey.Key(c)})
+	}
+	for c := '0'; c <= '9'; c++ {
+		table = append(table, keyDef{string(c), []string{string(c)}, hotkey.Key(c)})
+	}
+
+	// 펑션키 F1~F24
+	for i := 1; i <= 24; i++ {
+		name := fmt.Sprintf("F%d", i)
+		table = append(table, keyDef{name, []string{fmt.Sprintf("f%d", i)}, hotkey.Key(0xffbe + i - 1)})
+	}
+
+	// 숫자 패드
+	for i := 0; i <= 9; i++ {
+		table = append(table, keyDef{fmt.Sprintf("Num%d", i), []string{fmt.Sprintf("num%d", i), fmt.Sprintf("numpad%d", i)}, hotkey.Key(0xffb0 + i)})
+	}
+
+	return append(table,
+		keyDef{"NumMultiply", []string{"nummultiply", "multiply"}, 0xffaa},
+		keyDef{"NumAdd", []string{"numadd", "add"}, 0xffab},
+		keyDef{"NumSubtract", []string{"numsubtract", "subtract"}, 0xffad},
+		keyDef{"NumDecimal", []string{"numdecimal", "decimal"}, 0xffae},
+		keyDef{"NumDivide", []string{"numdivide", "divide"}, 0xffaf},
+
+		// 방향키, 편집키
+		keyDef{"Left", []string{"left"}, 0xff51},
+		keyDef{"Up", []string{"up"}, 0xff52},
+		keyDef{"Right", []string{"right"}, 0xff53},
+		keyDef{"Down", []string{"down"}, 0xff54},
+		keyDef{"Home", []string{"home"}, 0xff50},
+		keyDef{"End", []string{"end"}, 0xff57},
+		keyDef{"PageUp", []string{"pageup", "pgup"}, 0xff55},
+		keyDef{"PageDown", []string{"pagedown", "pgdn"}, 0xff56},
+		keyDef{"Insert", []string{"insert", "ins"}, 0xff63},
+		keyDef{"Delete", []string{"delete", "del"}, 0xffff},
+
+		// 기타
+		keyDef{"Space", []string{"space"}, 0x0020},
+		keyDef{"Enter", []string{"enter", "return"}, 0xff0d},
+		keyDef{"Tab", []string{"tab"}, 0xff09},
+		keyDef{"Esc", []string{"esc", "escape"}, 0xff1b},
+		keyDef{"Backspace", []string{"backspace"}, 0xff08},
+		keyDef{"Pause", []string{"pause", "break"}, 0xff13},
+		keyDef{"CapsLock", []string{"capslock"}, 0xffe5},
+		keyDef{"PrintScreen", []string{"printscreen", "prtsc"}, 0xff61},
+		keyDef{"ScrollLock", []string{"scrolllock"}, 0xff14},
+		keyDef{"NumLock", []string{"numlock"}, 0xff7f},
+
+		// 문장 부호 (US 배열 기준)
+		keyDef{";", []string{";", "semicolon"}, 0x003b},
+		keyDef{"=", []string{"=", "equal", "plus"}, 0x003d},
+		keyDef{"Comma", []string{"comma"}, 0x002c}, // ","는 연속 입력 구분자라 이름으로만 씀
+		keyDef{"-", []string{"-", "minus"}, 0x002d},
+		keyDef{".", []string{".", "period"}, 0x002e},
+		keyDef{"/", []string{"/", "slash"}, 0x002f},
+		keyDef{"`", []string{"`", "backquote", "grave"}, 0x0060},
+		keyDef{"[", []string{"[", "bracketleft"}, 0x005b},
+		keyDef{"\\", []string{"\\", "backslash"}, 0x005c},
+		keyDef{"]", []string{"]", "bracketright"}, 0x005d},
+		keyDef{"'", []string{"'", "quote"}, 0x0027},
+	)
+}()
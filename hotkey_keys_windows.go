@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+
+	"golang.design/x/hotkey"
+)
+
+// modifierTable 수정자 키 (Windows)
+var modifierTable = []modifierDef{
+	{"Ctrl", []string{"ctrl", "control"}, hotkey.ModCtrl},
+	{"Alt", []string{"alt"}, hotkey.ModAlt},
+	{"Shift", []string{"shift"}, hotkey.ModShift},
+	{"Win", []string{"win", "windows", "cmd", "super", "meta"}, hotkey.ModWin},
+}
+
+// keyTable 일반 키 (Windows 가상 키 코드)
+var keyTable = func() []keyDef {
+	var table []keyDef
+
+	// 알파벳, 숫자
+	for c := 'A'; c <= 'Z'; c++ {
+		table = append(table, keyDef{string(c), []string{string(c + 'a' - 'A')}, hotkey.Key(c)})
+	}
+	for c := '0'; c <= '9'; c++ {
+		table = append(table, keyDef{string(c), []string{string(c)}, hotkey.Key(c)})
+	}
+
+	// 펑션키 F1~F24
+	for i := 1; i <= 24; i++ {
+		name := fmt.Sprintf("F%d", i)
+		table = append(table, keyDef{name, []string{fmt.Sprintf("f%d", i)}, hotkey.Key(0x70 + i - 1)})
+	}
+
+	// 숫자 패드
+	for i := 0; i <= 9; i++ {
+		table = append(table, keyDef{fmt.Sprintf("Num%d", i), []string{fmt.Sprintf("num%d", i), fmt.Sprintf("numpad%d", i)}, hotkey.Key(0x60 + i)})
+	}
+
+	return append(table,
+		keyDef{"NumMultiply", []string{"nummultiply", "multiply"}, 0x6A},
+		keyDef{"NumAdd", []string{"numadd", "add"}, 0x6B},
+		keyDef{"NumSubtract", []string{"numsubtract", "subtract"}, 0x6D},
+		keyDef{"NumDecimal", []string{"numdecimal", "decimal"}, 0x6E},
+		keyDef{"NumDivide", []string{"numdivide", "divide"}, 0x6F},
+
+		// 방향키, 편집키
+		keyDef{"Left", []string{"left"}, 0x25},
+		keyDef{"Up", []string{"up"}, 0x26},
+		keyDef{"Right", []string{"right"}, 0x27},
+		keyDef{"Down", []string{"down"}, 0x28},
+		keyDef{"Home", []string{"home"}, 0x24},
+		keyDef{"End", []string{"end"}, 0x23},
+		keyDef{"PageUp", []string{"pageup", "pgup"}, 0x21},
+		keyDef{"PageDown", []string{"pagedown", "pgdn"}, 0x22},
+		keyDef{"Insert", []string{"insert", "ins"}, 0x2D},
+		keyDef{"Delete", []string{"delete", "del"}, 0x2E},
+
+		// 기타
+		keyDef{"Space", []string{"space"}, 0x20},
+		keyDef{"Enter", []string{"enter", "return"}, 0x0D},
+		keyDef{"Tab", []string{"tab"}, 0x09},
+		keyDef{"Esc", []string{"esc", "escape"}, 0x1B},
+		keyDef{"Backspace", []string{"backspace"}, 0x08},
+		keyDef{"Pause", []string{"pause", "break"}, 0x13},
+		keyDef{"CapsLock", []string{"capslock"}, 0x14},
+		keyDef{"PrintScreen", []string{"printscreen", "prtsc"}, 0x2C},
+		keyDef{"ScrollLock", []string{"scrolllock"}, 0x91},
+		keyDef{"NumLock", []string{"numlock"}, 0x90},
+
+		// 문장 부호 (US 배열 기준)
+		keyDef{";", []string{";", "semicolon"}, 0xBA},
+		keyDef{"=", []string{"=", "equal", "plus"}, 0xBB},
+		keyDef{"Comma", []string{"comma"}, 0xBC}, // ","는 연속 입력 구분자라 이름으로만 씀
+		keyDef{"-", []string{"-", "minus"}, 0xBD},
+		keyDef{".", []string{".", "period"}, 0xBE},
+		keyDef{"/", []string{"/", "slash"}, 0xBF},
+		keyDef{"`", []string{"`", "backquote", "grave"}, 0xC0},
+		keyDef{"[", []string{"[", "bracketleft"}, 0xDB},
+		keyDef{"\\", []string{"\\", "backslash"}, 0xDC},
+		keyDef{"]", []string{"]", "bracketright"}, 0xDD},
+		keyDef{"'", []string{"'", "quote"}, 0xDE},
+
+		// 미디어 키
+		keyDef{"VolumeMute", []string{"volumemute", "mute"}, 0xAD},
+		keyDef{"VolumeDown", []string{"volumedown"}, 0xAE},
+		keyDef{"VolumeUp", []string{"volumeup"}, 0xAF},
+		keyDef{"MediaNext", []string{"medianext"}, 0xB0},
+		keyDef{"MediaPrev", []string{"mediaprev"}, 0xB1},
+		keyDef{"MediaStop", []string{"mediastop"}, 0xB2},
+		keyDef{"MediaPlayPause", []string{"mediaplaypause", "mediaplay"}, 0xB3},
+	)
+}()
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseHotkeyCombo(t *testing.T) {
+	tests := []struct {
+		combo string
+		want  string
+	}{
+		{"ctrl+shift+b", "Ctrl+Shift+B"},
+		{"Shift+Ctrl+B", "Ctrl+Shift+B"},
+		{" alt + f10 ", "Alt+F10"},
+		{"control+alt+shift+f9", "Ctrl+Alt+Shift+F9"},
+		{"win+1", "Win+1"},
+		{"super+space", "Win+Space"},
+		{"f24", "F24"},
+		{"ctrl+pgup", "Ctrl+PageUp"},
+		{"ctrl+comma", "Ctrl+Comma"},
+		{"ctrl+semicolon", "Ctrl+;"},
+		{"ctrl+;", "Ctrl+;"},
+		{"alt+escape", "Alt+Esc"},
+	}
+	for _, tt := range tests {
+		spec, err := parseHotkeyCombo(tt.combo)
+		if err != nil {
+			t.Errorf("parseHotkeyCombo(%q): %v", tt.combo, err)
+			continue
+		}
+		if got := spec.String(); got != tt.want {
+			t.Errorf("parseHotkeyCombo(%q) = %q, want %q", tt.combo, got, tt.want)
+		}
+	}
+}
+
+func TestParseHotkeyComboErrors(t *testing.T) {
+	tests := []struct {
+		combo string
+		err   error
+	}{
+		{"ctrl++b", errEmptyToken},
+		{"", errEmptyToken},
+		{"ctrl+ctrl+b", errDuplicateModifier},
+		{"ctrl+control+b", errDuplicateModifier},
+		{"ctrl+shift", errMissingKey},
+		{"ctrl+a+b", errMultipleKeys},
+		{"ctrl+nosuchkey", errUnknownKey},
+		{"ctrl+,", errUnknownKey}, // 쉼표 키는 comma로만
+	}
+	for _, tt := range tests {
+		_, err := parseHotkeyCombo(tt.combo)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("parseHotkeyCombo(%q) error = %v, want %v", tt.combo, err, tt.err)
+		}
+	}
+}
+
+func TestCanonicalCombo(t *testing.T) {
+	tests := []struct {
+		combo string
+		want  string
+	}{
+		{"ctrl+shift+b", "Ctrl+Shift+B"},
+		{"ctrl+alt+b,1", "Ctrl+Alt+B, 1"},
+		{"ctrl+alt+b , r", "Ctrl+Alt+B, R"},
+		{"ctrl+alt+b, comma", "Ctrl+Alt+B, Comma"},
+		// 파싱할 수 없는 단계는 그대로
+		{"ctrl+nosuchkey", "ctrl+nosuchkey"},
+		{"ctrl+b, ctrl+nosuchkey", "Ctrl+B, ctrl+nosuchkey"},
+	}
+	for _, tt := range tests {
+		if got := canonicalCombo(tt.combo); got != tt.want {
+			t.Errorf("canonicalCombo(%q) = %q, want %q", tt.combo, got, tt.want)
+		}
+	}
+}
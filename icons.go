@@ -0,0 +1,34 @@
+package main
+
+import (
+	_ "embed"
+)
+
+// 트레이 아이콘 (상태별)
+var (
+	//go:embed icons/idle.ico
+	iconIdle []byte
+	//go:embed icons/busy.ico
+	iconBusy []byte
+	//go:embed icons/paused.ico
+	iconPaused []byte
+	//go:embed icons/error.ico
+	iconError []byte
+	//go:embed icons/missing.ico
+	iconMissing []byte
+)
+
+func stateIcon(state trayState) []byte {
+	switch state {
+	case stateBusy:
+		return iconBusy
+	case statePaused:
+		return iconPaused
+	case stateError:
+		return iconError
+	case stateMissing:
+		return iconMissing
+	default:
+		return iconIdle
+	}
+}
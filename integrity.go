@@ -0,0 +1,124 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// errSaveQuarantined 손상이 의심되어 백업 대신 격리했음을 나타내는 오류
+var errSaveQuarantined = errors.New("손상 의심 세이브를 격리했습니다")
+
+// saveValidators 프로필의 validators에 지정할 수 있는 세이브 형식 검사
+var saveValidators = map[string]func(data []byte) error{
+	"gvas": validateGVAS,
+	"json": validateJSON,
+	"zip":  validateZip,
+}
+
+// validateGVAS Unreal Engine SaveGame 파일은 "GVAS"로 시작
+func validateGVAS(data []byte) error {
+	if !bytes.HasPrefix(data, []byte("GVAS")) {
+		return fmt.Errorf("Unreal 세이브 헤더(GVAS)가 없습니다")
+	}
+	return nil
+}
+
+func validateJSON(data []byte) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("올바른 JSON이 아닙니다")
+	}
+	return nil
+}
+
+func validateZip(data []byte) error {
+	if _, err := zip.NewReader(bytes.NewReader(data), int64(len(data))); err != nil {
+		return fmt.Errorf("올바른 ZIP이 아닙니다: %v", err)
+	}
+	return nil
+}
+
+// checkSourceSave 백업 전에 세이브 파일 검사
+// 비어 있거나 min_save_size보다 작거나 프로필의 validators를 통과하지 못하면
+// 정상 백업을 밀어내지 않도록 격리 폴더에만 복사하고 오류 반환
+func checkSourceSave(target saveTarget) error {
+	sourceFile := target.TargetFile
+	info, err := os.Stat(sourceFile)
+	if err != nil {
+		return fmt.Errorf("백업할 파일이 존재하지 않습니다: %s", sourceFile)
+	}
+
+	reason := ""
+	if info.Size() == 0 || info.Size() < target.Profile.MinSaveSize {
+		reason = fmt.Sprintf("세이브 파일 크기가 %d바이트", info.Size())
+	} else if len(target.Profile.Validators) > 0 {
+		data, err := os.ReadFile(sourceFile)
+		if err != nil {
+			return fmt.Errorf("세이브 파일 읽기 실패: %v", err)
+		}
+		for _, name := range target.Profile.Validators {
+			validate, ok := saveValidators[name]
+			if !ok {
+				log.Printf("알 수 없는 세이브 검사입니다: %s", name)
+				continue
+			}
+			if err := validate(data); err != nil {
+				reason = fmt.Sprintf("%s 검사 실패 (%v)", name, err)
+				break
+			}
+		}
+	}
+
+	if reason != "" {
+		quarantinePath, err := quarantineSave(target)
+		if err != nil {
+			return fmt.Errorf("손상 의심 세이브 (%s) 격리 실패: %v", reason, err)
+		}
+		notify(notifyQuarantine, "손상 의심 세이브 격리", targetMessage(target,
+			fmt.Sprintf("%s. 백업하지 않고 격리했습니다.\n%s", reason, quarantinePath)))
+		return fmt.Errorf("%w (%s): %s", errSaveQuarantined, reason, quarantinePath)
+	}
+
+	detectRollback(target, info.ModTime())
+	return nil
+}
+
+// quarantineSave 손상 의심 세이브를 백업 폴더의 quarantine 폴더로 복사
+func quarantineSave(target saveTarget) (string, error) {
+	sourceFile := target.TargetFile
+	ext := filepath.Ext(sourceFile)
+	name := fmt.Sprintf("%s_%s%s", strings.TrimSuffix(filepath.Base(sourceFile), ext), time.Now().Format("20060102_150405"), ext)
+	quarantinePath := uniqueBackupPath(filepath.Join(target.BackupDir, "quarantine", name))
+
+	if err := copyFile(sourceFile, quarantinePath); err != nil {
+		return "", err
+	}
+	log.Printf("손상 의심 세이브 격리: %s", quarantinePath)
+	return quarantinePath, nil
+}
+
+// detectRollback 세이브 수정 시각이 그 세이브의 가장 최근 백업 당시보다 이전이면 알림
+// (클라우드 동기화나 다른 도구가 오래된 세이브로 되돌린 경우)
+func detectRollback(target saveTarget, modTime time.Time) {
+	var latest catalogEntry
+	for _, entry := range readCatalog(target.BackupDir) {
+		if entry.belongsTo(target) && entry.Created.After(latest.Created) {
+			latest = entry
+		}
+	}
+
+	if latest.SourceModTime.IsZero() || !modTime.Before(latest.SourceModTime) {
+		return
+	}
+
+	notify(notifyRollback, "세이브 되돌림 감지", targetMessage(target,
+		fmt.Sprintf("세이브 파일이 %s 시점으로 되돌아갔습니다 (마지막 백업 당시 %s).",
+			modTime.Format("01-02 15:04:05"), latest.SourceModTime.Format("01-02 15:04:05"))))
+}
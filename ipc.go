@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// 트레이 인스턴스와 명령줄 하위 명령 사이의 통신
+// 트레이 인스턴스가 127.0.0.1의 임의 포트에서 대기하고, 주소와 토큰을 상태 폴더의 ipc.json에 기록
+
+type ipcEndpoint struct {
+	Addr  string `json:"addr"`
+	Token string `json:"token"`
+}
+
+var ipcListener net.Listener
+
+// ipcHandlers 요청 이름 → 응답(JSON으로 직렬화)
+var ipcHandlers = map[string]func() any{
+	"hotkeys status": func() any { return hotkeyStatusList() },
+	"config reload": func() any {
+		if err := reloadConfig(); err != nil {
+			return map[string]string{"error": err.Error()}
+		}
+		return map[string]string{}
+	},
+}
+
+func ipcEndpointPath() (string, error) {
+	return stateFilePath("ipc.json")
+}
+
+// startIPCServer 트레이 인스턴스에서 요청 대기 시작
+func startIPCServer() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Printf("IPC 서버 시작 실패: %v", err)
+		return
+	}
+
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		log.Printf("IPC 토큰 생성 실패: %v", err)
+		listener.Close()
+		return
+	}
+	endpoint := ipcEndpoint{Addr: listener.Addr().String(), Token: hex.EncodeToString(tokenBytes)}
+
+	data, _ := json.Marshal(endpoint)
+	path, err := ipcEndpointPath()
+	if err == nil {
+		err = os.WriteFile(path, data, 0600)
+	}
+	if err != nil {
+		log.Printf("IPC 주소 저장 실패: %v", err)
+		listener.Close()
+		return
+	}
+
+	ipcListener = listener
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handleIPCConn(conn, endpoint.Token)
+		}
+	}()
+}
+
+func stopIPCServer() {
+	if ipcListener != nil {
+		ipcListener.Close()
+		ipcListener = nil
+		if path, err := ipcEndpointPath(); err == nil {
+			os.Remove(path)
+		}
+	}
+}
+
+// handleIPCConn 첫 줄은 토큰, 둘째 줄은 요청 이름. 응답은 JSON 한 개
+func handleIPCConn(conn net.Conn, token string) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	reader := bufio.NewReader(conn)
+	gotToken, _ := reader.ReadString('\n')
+	request, _ := reader.ReadString('\n')
+	if strings.TrimSpace(gotToken) != token {
+		return
+	}
+
+	handler, ok := ipcHandlers[strings.TrimSpace(request)]
+	if !ok {
+		json.NewEncoder(conn).Encode(map[string]string{"error": "알 수 없는 요청입니다"})
+		return
+	}
+	json.NewEncoder(conn).Encode(handler())
+}
+
+// queryIPC 실행 중인 트레이 인스턴스에 요청을 보내고 응답을 result에 디코딩
+func queryIPC(request string, result any) error {
+	path, err := ipcEndpointPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("실행 중인 SB Backup Creator가 없습니다")
+	}
+	var endpoint ipcEndpoint
+	if err := json.Unmarshal(data, &endpoint); err != nil {
+		return fmt.Errorf("IPC 주소 파싱 실패: %v", err)
+	}
+
+	conn, err := net.DialTimeout("tcp", endpoint.Addr, 2*time.Second)
+	if err != nil {
+		return fmt.Errorf("실행 중인 SB Backup Creator에 연결할 수 없습니다: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := fmt.Fprintf(conn, "%s\n%s\n", endpoint.Token, request); err != nil {
+		return fmt.Errorf("IPC 요청 실패: %v", err)
+	}
+	if err := json.NewDecoder(conn).Decode(result); err != nil {
+		return fmt.Errorf("IPC 응답 파싱 실패: %v", err)
+	}
+	return nil
+}
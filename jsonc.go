@@ -0,0 +1,586 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// 주석이 있는 JSON (JSONC)
+// settings.json에는 // 한 줄 주석, /* */ 블록 주석, 끝에 남은 쉼표를 쓸 수 있음
+// 읽을 때는 주석과 끝 쉼표를 같은 길이의 공백으로 바꿔서 표준 JSON으로 읽고 (오류 위치의 줄/열이 그대로 맞음),
+// 저장할 때는 기존 파일에서 바뀐 값만 고쳐 써서 주석과 항목 순서를 유지
+
+// stripJSONC 주석, 끝 쉼표, UTF-8 BOM을 공백으로 바꾼 복사본 (줄바꿈과 길이는 그대로)
+func stripJSONC(data []byte) []byte {
+	out := append([]byte(nil), data...)
+	if bytes.HasPrefix(out, []byte("\xef\xbb\xbf")) {
+		copy(out, "   ")
+	}
+
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			if out[i] != '\n' && out[i] != '\r' {
+				out[i] = ' '
+			}
+		}
+	}
+
+	lastComma := -1 // 아직 뒤에 값이 나오지 않은 쉼표 위치
+	for i := 0; i < len(out); i++ {
+		switch c := out[i]; {
+		case c == '"':
+			lastComma = -1
+			for i++; i < len(out) && out[i] != '"'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			end := bytes.IndexByte(out[i:], '\n')
+			if end < 0 {
+				end = len(out) - i
+			}
+			blank(i, i+end)
+			i += end - 1
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			if end < 0 {
+				end = len(out) - i - 2
+			} else {
+				end += 2
+			}
+			blank(i, i+2+end)
+			i += 2 + end - 1
+		case c == ',':
+			lastComma = i
+		case c == '}' || c == ']':
+			if lastComma >= 0 {
+				out[lastComma] = ' '
+			}
+			lastComma = -1
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		default:
+			lastComma = -1
+		}
+	}
+	return out
+}
+
+// jsonNode JSON 값 하나와 원문에서의 위치
+type jsonNode struct {
+	start, end int
+	kind       byte // '{', '[', 그 외는 문자열/숫자/true/false/null
+	members    []jsonMember
+	elements   []*jsonNode
+}
+
+// jsonMember 객체 항목 하나
+type jsonMember struct {
+	key   string
+	start int // 키의 따옴표 위치
+	value *jsonNode
+}
+
+type jsonParser struct {
+	data []byte
+	pos  int
+}
+
+// parseJSONNodes 표준 JSON(주석을 지운 JSONC 포함)의 값 구조와 위치
+func parseJSONNodes(data []byte) (*jsonNode, error) {
+	p := &jsonParser{data: data}
+	node, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.data) {
+		return nil, fmt.Errorf("%d 위치 뒤에 남은 내용이 있습니다", p.pos)
+	}
+	return node, nil
+}
+
+func (p *jsonParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.data) || p.data[p.pos] != c {
+		return fmt.Errorf("%d 위치에 %q가 있어야 합니다", p.pos, c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *jsonParser) value() (*jsonNode, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, fmt.Errorf("JSON 값이 없습니다")
+	}
+
+	node := &jsonNode{start: p.pos, kind: p.data[p.pos]}
+	switch node.kind {
+	case '{':
+		p.pos++
+		for p.skipSpace(); p.pos < len(p.data) && p.data[p.pos] != '}'; p.skipSpace() {
+			keyStart := p.pos
+			if err := p.skipString(); err != nil {
+				return nil, err
+			}
+			var key string
+			if err := json.Unmarshal(p.data[keyStart:p.pos], &key); err != nil {
+				return nil, fmt.Errorf("%d 위치의 키를 읽을 수 없습니다: %v", keyStart, err)
+			}
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			node.members = append(node.members, jsonMember{key: key, start: keyStart, value: value})
+			if p.skipSpace(); p.pos < len(p.data) && p.data[p.pos] == ',' {
+				p.pos++
+			}
+		}
+		if err := p.expect('}'); err != nil {
+			return nil, err
+		}
+	case '[':
+		p.pos++
+		for p.skipSpace(); p.pos < len(p.data) && p.data[p.pos] != ']'; p.skipSpace() {
+			element, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			node.elements = append(node.elements, element)
+			if p.skipSpace(); p.pos < len(p.data) && p.data[p.pos] == ',' {
+				p.pos++
+			}
+		}
+		if err := p.expect(']'); err != nil {
+			return nil, err
+		}
+	case '"':
+		if err := p.skipString(); err != nil {
+			return nil, err
+		}
+	default:
+		for p.pos < len(p.data) && bytes.IndexByte([]byte(",}] \t\r\n"), p.data[p.pos]) < 0 {
+			p.pos++
+		}
+		if p.pos == node.start {
+			return nil, fmt.Errorf("%d 위치에 JSON 값이 있어야 합니다", p.pos)
+		}
+	}
+	node.end = p.pos
+	return node, nil
+}
+
+func (p *jsonParser) skipString() error {
+	if p.pos >= len(p.data) || p.data[p.pos] != '"' {
+		return fmt.Errorf("%d 위치에 문자열이 있어야 합니다", p.pos)
+	}
+	for p.pos++; p.pos < len(p.data); p.pos++ {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			return nil
+		}
+	}
+	return fmt.Errorf("문자열이 끝나지 않았습니다")
+}
+
+// textEdit 원문의 [start, end)를 text로 바꾸기
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// jsoncPatch 기존 JSONC 원문을 새 JSON 내용에 맞게 고치는 중의 상태
+type jsoncPatch struct {
+	original []byte // 주석이 있는 원문
+	stripped []byte // 주석을 지운 원문 (위치는 원문과 같음)
+	updated  []byte // 새 내용 (표준 JSON)
+	unit     string // 원문의 들여쓰기 단위
+	newline  string // 원문의 줄바꿈 (\n 또는 \r\n)
+	edits    []textEdit
+}
+
+// patchJSONC 원문(JSONC)에서 새 내용과 다른 값만 바꿔 씀. 주석, 항목 순서, 바뀌지 않은 부분의 모양은 그대로
+// 새 항목은 객체 끝에 새 내용의 순서대로 추가하고, 없어진 항목은 지움
+func patchJSONC(original, updated []byte) ([]byte, error) {
+	stripped := stripJSONC(original)
+	oldRoot, err := parseJSONNodes(stripped)
+	if err != nil {
+		return nil, err
+	}
+	newRoot, err := parseJSONNodes(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := &jsoncPatch{original: original, stripped: stripped, updated: updated, unit: "  ", newline: "\n"}
+	if bytes.Contains(original, []byte("\r\n")) {
+		patch.newline = "\r\n"
+	}
+	if oldRoot.kind == '{' && len(oldRoot.members) > 0 {
+		if indent := patch.memberIndent(oldRoot); indent != "" {
+			patch.unit = indent
+		}
+	}
+	patch.diff(oldRoot, newRoot)
+
+	// 뒤에서부터 적용 (같은 위치면 지우기를 먼저)
+	sort.Slice(patch.edits, func(i, j int) bool {
+		if patch.edits[i].start != patch.edits[j].start {
+			return patch.edits[i].start > patch.edits[j].start
+		}
+		return patch.edits[i].end > patch.edits[j].end
+	})
+	result := append([]byte(nil), original...)
+	for _, edit := range patch.edits {
+		result = append(result[:edit.start], append([]byte(edit.text), result[edit.end:]...)...)
+	}
+
+	// 고친 결과가 새 내용과 같은 값인지 확인 (다르면 호출하는 쪽에서 새로 씀)
+	var got, want any
+	if err := json.Unmarshal(stripJSONC(result), &got); err != nil {
+		return nil, fmt.Errorf("고친 설정 파일을 읽을 수 없습니다: %v", err)
+	}
+	if err := json.Unmarshal(updated, &want); err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(got, want) {
+		return nil, fmt.Errorf("고친 설정 파일이 새 설정과 다릅니다")
+	}
+	return result, nil
+}
+
+// diff 같은 위치의 예전 값과 새 값 비교
+func (p *jsoncPatch) diff(old, updated *jsonNode) {
+	if sameJSON(p.stripped[old.start:old.end], p.updated[updated.start:updated.end]) {
+		return
+	}
+
+	switch {
+	case old.kind == '{' && updated.kind == '{':
+		p.diffObject(old, updated)
+	case old.kind == '[' && updated.kind == '[' && len(old.elements) == len(updated.elements):
+		for i := range old.elements {
+			p.diff(old.elements[i], updated.elements[i])
+		}
+	default:
+		p.replace(old, updated)
+	}
+}
+
+// diffObject 예전 항목은 제자리에서 고치거나 지우고, 새 항목은 끝에 추가
+func (p *jsoncPatch) diffObject(old, updated *jsonNode) {
+	newMembers := make(map[string]*jsonNode, len(updated.members))
+	for _, member := range updated.members {
+		newMembers[member.key] = member.value
+	}
+
+	var kept []jsonMember
+	oldKeys := make(map[string]bool, len(old.members))
+	for _, member := range old.members {
+		oldKeys[member.key] = true
+		if value, ok := newMembers[member.key]; ok {
+			kept = append(kept, member)
+			p.diff(member.value, value)
+		}
+	}
+	if len(kept) == 0 && len(old.members) > 0 {
+		// 남는 항목이 없으면 객체 전체를 새로 씀
+		p.replace(old, updated)
+		return
+	}
+	keptBefore := false
+	for i, member := range old.members {
+		if _, ok := newMembers[member.key]; ok {
+			keptBefore = true
+			continue
+		}
+		p.remove(old, i, keptBefore)
+	}
+
+	var added []jsonMember
+	for _, member := range updated.members {
+		if !oldKeys[member.key] {
+			added = append(added, member)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	// 새 내용에서 남는 첫 항목보다 앞에 오는 항목($schema, version 등)은 맨 앞에 추가
+	var head []jsonMember
+	if len(kept) > 0 {
+		order := make(map[string]int, len(updated.members))
+		for i, member := range updated.members {
+			order[member.key] = i
+		}
+		first := order[kept[0].key]
+		for len(added) > 0 && order[added[0].key] < first {
+			head = append(head, added[0])
+			added = added[1:]
+		}
+	}
+
+	// 한 줄로 쓴 객체에는 같은 줄에 추가
+	if !p.multiline(old) {
+		inlineItems := func(members []jsonMember) string {
+			var items []string
+			for _, member := range members {
+				key, _ := json.Marshal(member.key)
+				items = append(items, string(key)+": "+p.inline(member.value))
+			}
+			return strings.Join(items, ", ")
+		}
+		if len(head) > 0 {
+			p.edits = append(p.edits, textEdit{start: kept[0].start, end: kept[0].start, text: inlineItems(head) + ", "})
+		}
+		if len(added) == 0 {
+			return
+		}
+		items := []string{inlineItems(added)}
+		if len(kept) == 0 {
+			p.edits = append(p.edits, textEdit{start: old.start, end: old.end, text: "{" + strings.Join(items, ", ") + "}"})
+		} else {
+			last := kept[len(kept)-1].value.end
+			p.edits = append(p.edits, textEdit{start: last, end: last, text: ", " + strings.Join(items, ", ")})
+		}
+		return
+	}
+
+	indent := p.memberIndent(old)
+	if indent == "" {
+		indent = p.lineIndent(old.start) + p.unit
+	}
+	memberLines := func(members []jsonMember) []string {
+		var lines []string
+		for _, member := range members {
+			key, _ := json.Marshal(member.key)
+			lines = append(lines, indent+string(key)+": "+p.reindent(member.value, indent))
+		}
+		return lines
+	}
+	if len(head) > 0 {
+		text := strings.TrimPrefix(strings.Join(memberLines(head), ","+p.newline), indent)
+		p.edits = append(p.edits, textEdit{start: kept[0].start, end: kept[0].start, text: text + "," + p.newline + p.lineIndent(kept[0].start)})
+	}
+	if len(added) == 0 {
+		return
+	}
+	lines := memberLines(added)
+
+	if len(kept) == 0 {
+		// 빈 객체: { } 안을 새 항목으로 채움
+		text := "{" + p.newline + strings.Join(lines, ","+p.newline) + p.newline + p.lineIndent(old.start) + "}"
+		p.edits = append(p.edits, textEdit{start: old.start, end: old.end, text: text})
+		return
+	}
+
+	// 마지막 항목 줄의 쉼표와 주석 뒤, 다음 줄부터 추가
+	last := kept[len(kept)-1].value.end
+	if lineEnd, hasComma, ok := p.lineTail(last); ok {
+		if kept[len(kept)-1].start != old.members[len(old.members)-1].start {
+			// 뒤의 항목을 지우면서 이 쉼표도 지움
+			hasComma = false
+		}
+		text := p.newline + strings.Join(lines, ","+p.newline)
+		switch {
+		case hasComma:
+		case lineEnd == last:
+			text = "," + text
+		default:
+			p.edits = append(p.edits, textEdit{start: last, end: last, text: ","})
+		}
+		p.edits = append(p.edits, textEdit{start: lineEnd, end: lineEnd, text: text})
+		return
+	}
+	p.edits = append(p.edits, textEdit{start: last, end: last, text: "," + p.newline + strings.Join(lines, ","+p.newline)})
+}
+
+// lineTail pos 뒤가 그 줄 끝까지 쉼표, 공백, 주석뿐이면 줄 끝 위치(줄바꿈 앞)와 쉼표가 있는지
+func (p *jsoncPatch) lineTail(pos int) (lineEnd int, hasComma, ok bool) {
+	i := pos
+	for i < len(p.original) {
+		switch c := p.original[i]; {
+		case c == '\r' || c == '\n':
+			return i, hasComma, true
+		case c == ' ' || c == '\t':
+			i++
+		case c == ',' && !hasComma:
+			hasComma = true
+			i++
+		case bytes.HasPrefix(p.original[i:], []byte("//")):
+			for i < len(p.original) && p.original[i] != '\r' && p.original[i] != '\n' {
+				i++
+			}
+		case bytes.HasPrefix(p.original[i:], []byte("/*")):
+			end := bytes.Index(p.original[i+2:], []byte("*/"))
+			if end < 0 || bytes.ContainsAny(p.original[i:i+2+end], "\r\n") {
+				return 0, false, false
+			}
+			i += 2 + end + 2
+		default:
+			return 0, false, false
+		}
+	}
+	return 0, false, false
+}
+
+// multiline 원문에서 여러 줄에 걸쳐 쓴 값인지
+func (p *jsoncPatch) multiline(node *jsonNode) bool {
+	return bytes.ContainsAny(p.original[node.start:node.end], "\n")
+}
+
+// remove 객체 항목 하나를 쉼표, 그 줄의 들여쓰기와 함께 지움
+// 앞에 남는 항목이 있으면 앞 항목 뒤의 쉼표를, 없으면 이 항목 뒤의 쉼표를 지워서 여러 항목을 지워도 겹치지 않음
+func (p *jsoncPatch) remove(object *jsonNode, index int, keptBefore bool) {
+	member := object.members[index]
+	start, end := member.start, member.value.end
+
+	comma := -1
+	if keptBefore {
+		for i := member.start - 1; i >= object.members[index-1].value.end; i-- {
+			if p.stripped[i] == ',' {
+				p.edits = append(p.edits, textEdit{start: i, end: i + 1})
+				comma = i
+				break
+			}
+		}
+	} else if comma := p.nextNonSpace(end); comma < len(p.stripped) && p.stripped[comma] == ',' {
+		end = comma + 1
+	}
+
+	// 항목만 있던 줄이면 줄째로 지움
+	lineStart := start
+	for lineStart > 0 && (p.original[lineStart-1] == ' ' || p.original[lineStart-1] == '\t') {
+		lineStart--
+	}
+	if lineStart > 0 && p.original[lineStart-1] == '\n' {
+		start = lineStart - 1
+		if start > 0 && p.original[start-1] == '\r' {
+			start--
+		}
+	} else if comma >= 0 && lineStart == comma+1 {
+		// 같은 줄의 앞 항목 뒤였으면 쉼표 뒤 공백까지 지움 ("a": 1, "b": 2 → "a": 1)
+		start = lineStart
+	} else if comma < 0 {
+		// 같은 줄의 다음 항목 앞 공백도 지움 ("a": 1, "b": 2 → "b": 2)
+		next := end
+		for next < len(p.original) && (p.original[next] == ' ' || p.original[next] == '\t') {
+			next++
+		}
+		if next < len(p.original) && strings.IndexByte("\r\n/", p.original[next]) < 0 {
+			end = next
+		}
+	}
+	p.edits = append(p.edits, textEdit{start: start, end: end})
+}
+
+// replace 예전 값을 새 값으로 바꿔 씀 (한 줄로 썼던 값은 한 줄로, 아니면 예전 값이 있던 줄에 맞춰 들여씀)
+func (p *jsoncPatch) replace(old, updated *jsonNode) {
+	text := p.inline(updated)
+	if p.multiline(old) {
+		text = p.reindent(updated, p.lineIndent(old.start))
+	}
+	p.edits = append(p.edits, textEdit{start: old.start, end: old.end, text: text})
+}
+
+// reindent 새 값을 prefix 들여쓰기 줄에 넣을 모양으로
+func (p *jsoncPatch) reindent(node *jsonNode, prefix string) string {
+	var b bytes.Buffer
+	if err := json.Indent(&b, p.updated[node.start:node.end], prefix, p.unit); err != nil {
+		return string(p.updated[node.start:node.end])
+	}
+	return strings.ReplaceAll(b.String(), "\n", p.newline)
+}
+
+// inline 새 값을 한 줄로 (["a", "b"], {"x": 1} 모양)
+func (p *jsoncPatch) inline(node *jsonNode) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, p.updated[node.start:node.end]); err != nil {
+		return string(p.updated[node.start:node.end])
+	}
+
+	var b strings.Builder
+	inString, escaped := false, false
+	for _, c := range compact.Bytes() {
+		b.WriteByte(c)
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case !inString && (c == ',' || c == ':'):
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+// lineIndent pos가 있는 줄의 들여쓰기
+func (p *jsoncPatch) lineIndent(pos int) string {
+	start := bytes.LastIndexByte(p.original[:pos], '\n') + 1
+	end := start
+	for end < pos && (p.original[end] == ' ' || p.original[end] == '\t') {
+		end++
+	}
+	return string(p.original[start:end])
+}
+
+// memberIndent 객체 항목의 들여쓰기 (첫 항목이 줄 맨 앞에 있을 때만, 아니면 빈 문자열)
+func (p *jsoncPatch) memberIndent(object *jsonNode) string {
+	if len(object.members) == 0 {
+		return ""
+	}
+	start := object.members[0].start
+	indent := p.lineIndent(start)
+	lineStart := bytes.LastIndexByte(p.original[:start], '\n') + 1
+	if lineStart+len(indent) != start {
+		return ""
+	}
+	return indent
+}
+
+func (p *jsoncPatch) nextNonSpace(pos int) int {
+	for pos < len(p.stripped) {
+		switch p.stripped[pos] {
+		case ' ', '\t', '\r', '\n':
+			pos++
+		default:
+			return pos
+		}
+	}
+	return pos
+}
+
+// sameJSON 공백을 빼고 같은 값인지
+func sameJSON(a, b []byte) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return false
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
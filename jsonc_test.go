@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStripJSONC(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", `{"a": 1}`, `{"a": 1}`},
+		{"line comment", "{\"a\": 1 // x\n}", "{\"a\": 1     \n}"},
+		{"block comment", `{/* x */"a": 1}`, `{       "a": 1}`},
+		{"multiline block comment keeps newlines", "{/*\nx\n*/\"a\": 1}", "{  \n \n  \"a\": 1}"},
+		{"trailing comma in object", `{"a": 1,}`, `{"a": 1 }`},
+		{"trailing comma in array", "[1, 2,\n]", "[1, 2 \n]"},
+		{"trailing comma before comment", "[1, // x\n]", "[1      \n]"},
+		{"slashes in string", `{"a": "http://x/*y*/"}`, `{"a": "http://x/*y*/"}`},
+		{"escaped quote in string", `{"a": "\"//"}`, `{"a": "\"//"}`},
+		{"comma in string", `{"a": ",}"}`, `{"a": ",}"}`},
+		{"bom", "\xef\xbb\xbf{}", "   {}"},
+	}
+	for _, tt := range tests {
+		got := string(stripJSONC([]byte(tt.in)))
+		if got != tt.want {
+			t.Errorf("%s: stripJSONC(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+		if len(got) != len(tt.in) {
+			t.Errorf("%s: stripJSONC changed length %d → %d", tt.name, len(tt.in), len(got))
+		}
+	}
+}
+
+// patchJSONCTests 원래 파일, 저장할 내용, 기대하는 결과
+var patchJSONCTests = []struct {
+	name     string
+	original string
+	updated  string
+	want     string
+}{
+	{
+		"change value keeps comments",
+		"{\n  // 메모\n  \"a\": 1, // 값\n  \"b\": \"x\"\n}\n",
+		`{"a":2,"b":"x"}`,
+		"{\n  // 메모\n  \"a\": 2, // 값\n  \"b\": \"x\"\n}\n",
+	},
+	{
+		"remove middle member",
+		"{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 3\n}\n",
+		`{"a":1,"c":3}`,
+		"{\n  \"a\": 1,\n  \"c\": 3\n}\n",
+	},
+	{
+		"remove trailing members",
+		"{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 3\n}\n",
+		`{"a":1}`,
+		"{\n  \"a\": 1\n}\n",
+	},
+	{
+		"remove leading members",
+		"{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 3\n}\n",
+		`{"c":3}`,
+		"{\n  \"c\": 3\n}\n",
+	},
+	{
+		"add member after trailing comma",
+		"{\n  \"a\": 1, // a\n  \"b\": 2,\n}\n",
+		`{"a":1,"b":2,"c":true}`,
+		"{\n  \"a\": 1, // a\n  \"b\": 2,\n  \"c\": true\n}\n",
+	},
+	{
+		"add leading member",
+		"{\n  \"a\": 1 /* a */\n}\n",
+		`{"$schema":"s","a":1}`,
+		"{\n  \"$schema\": \"s\",\n  \"a\": 1 /* a */\n}\n",
+	},
+	{
+		"inline object replace member",
+		`{"a": 1, "b": 2}`,
+		`{"a":1,"c":3}`,
+		`{"a": 1, "c": 3}`,
+	},
+	{
+		"inline object remove leading members",
+		`{"a": 1, "b": 2, "c": 3}`,
+		`{"c":3}`,
+		`{"c": 3}`,
+	},
+	{
+		"inline object add leading member",
+		`{"a": 1}`,
+		`{"z":0,"a":1}`,
+		`{"z": 0, "a": 1}`,
+	},
+	{
+		"fill empty object",
+		"{\n  \"a\": {}\n}\n",
+		`{"a":{"x":1}}`,
+		"{\n  \"a\": {\"x\": 1}\n}\n",
+	},
+	{
+		"inline array shrinks",
+		"{\n  \"list\": [1, 2, 3], // l\n  \"n\": null\n}\n",
+		`{"list":[1,2],"n":null}`,
+		"{\n  \"list\": [1, 2], // l\n  \"n\": null\n}\n",
+	},
+	{
+		"array element member",
+		"{\n  \"p\": [\n    {\"name\": \"A\", \"max\": 1}, // A\n    {\"name\": \"B\", \"max\": 2}\n  ]\n}\n",
+		`{"p":[{"name":"A","max":5},{"name":"B","max":2}]}`,
+		"{\n  \"p\": [\n    {\"name\": \"A\", \"max\": 5}, // A\n    {\"name\": \"B\", \"max\": 2}\n  ]\n}\n",
+	},
+	{
+		"crlf",
+		"{\r\n  \"a\": 1 // x\r\n}\r\n",
+		`{"a":1,"b":{"c":[1]}}`,
+		"{\r\n  \"a\": 1, // x\r\n  \"b\": {\r\n    \"c\": [\r\n      1\r\n    ]\r\n  }\r\n}\r\n",
+	},
+	{
+		"bom and slashes in string",
+		"\xef\xbb\xbf{\n  \"a\": \"http://x//y\" // url\n}\n",
+		`{"a":"http://z//w"}`,
+		"\xef\xbb\xbf{\n  \"a\": \"http://z//w\" // url\n}\n",
+	},
+	{
+		"four space indent",
+		"{\n    \"a\": 1\n}\n",
+		`{"a":1,"b":{"c":1,"d":[1,2]}}`,
+		"{\n    \"a\": 1,\n    \"b\": {\n        \"c\": 1,\n        \"d\": [\n            1,\n            2\n        ]\n    }\n}\n",
+	},
+	{
+		"escaped string value",
+		"{\n  \"a\": 1\n}\n",
+		`{"a":1,"b":{"c":"q\"\\,:"}}`,
+		"{\n  \"a\": 1,\n  \"b\": {\n    \"c\": \"q\\\"\\\\,:\"\n  }\n}\n",
+	},
+}
+
+func TestPatchJSONC(t *testing.T) {
+	for _, tt := range patchJSONCTests {
+		got, err := patchJSONC([]byte(tt.original), []byte(tt.updated))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestPatchJSONCRoundTrip 결과를 읽으면 저장할 내용과 같고, 같은 내용으로 다시 고쳐도 바뀌지 않음
+func TestPatchJSONCRoundTrip(t *testing.T) {
+	for _, tt := range patchJSONCTests {
+		got, err := patchJSONC([]byte(tt.original), []byte(tt.updated))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		var want, decoded any
+		if err := json.Unmarshal([]byte(tt.updated), &want); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if err := json.Unmarshal(stripJSONC(got), &decoded); err != nil {
+			t.Errorf("%s: result is not valid JSONC: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, want) {
+			t.Errorf("%s: result decodes to %v, want %v", tt.name, decoded, want)
+		}
+
+		again, err := patchJSONC(got, []byte(tt.updated))
+		if err != nil {
+			t.Errorf("%s: second patch: %v", tt.name, err)
+		} else if !bytes.Equal(again, got) {
+			t.Errorf("%s: second patch changed the file:\n%q\n%q", tt.name, got, again)
+		}
+
+		// 원래 내용 그대로 저장하면 파일이 바뀌지 않음
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, stripJSONC([]byte(tt.original))); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		same, err := patchJSONC([]byte(tt.original), compact.Bytes())
+		if err != nil {
+			t.Errorf("%s: unchanged patch: %v", tt.name, err)
+		} else if !bytes.Equal(same, []byte(tt.original)) {
+			t.Errorf("%s: unchanged content rewrote the file: %q", tt.name, same)
+		}
+	}
+}
+
+func TestPatchJSONCInvalid(t *testing.T) {
+	tests := []struct {
+		original string
+		updated  string
+	}{
+		{`{"a": }`, `{"a":1}`},
+		{`{"a": 1}`, `{"a":`},
+	}
+	for _, tt := range tests {
+		if _, err := patchJSONC([]byte(tt.original), []byte(tt.updated)); err == nil {
+			t.Errorf("patchJSONC(%q, %q): expected error", tt.original, tt.updated)
+		}
+	}
+}
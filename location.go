@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// 설정 파일과 상태 파일 위치
+// 설정 파일은 --config, 포터블 모드(실행 파일 옆), 운영체제 설정 폴더 순으로 찾음
+// 상태 파일(ipc.json, 로그, 백업 카탈로그)은 포터블 모드면 실행 파일 옆, 아니면 운영체제의 데이터/상태 폴더에 둠
+
+const (
+	appDirName         = "sb-backup-creator"
+	configFileName     = "settings.json"
+	portableMarkerName = "portable.txt" // 실행 파일 옆에 이 파일이 있으면 포터블 모드
+	logFileName        = "sb-backup-creator.log"
+
+	// 로그 파일이 이보다 크면 시작할 때 .old로 바꾸고 새로 씀
+	maxLogFileSize = 1 << 20
+)
+
+// --config로 지정한 설정 파일 경로 (없으면 빈 값)
+var configFlag string
+
+// exeDir 실행 파일이 있는 폴더
+func exeDir() (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("실행 파일 경로 가져오기 실패: %v", err)
+	}
+	return filepath.Dir(exePath), nil
+}
+
+// isPortable 실행 파일 옆에 포터블 표시 파일이나 (예전 버전이 만든) settings.json이 있는지
+func isPortable() bool {
+	dir, err := exeDir()
+	if err != nil {
+		return false
+	}
+	for _, name := range []string{portableMarkerName, "portable", configFileName} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveConfigPath 사용할 settings.json 경로
+// --config → 포터블 모드의 실행 파일 옆 → %APPDATA%, $XDG_CONFIG_HOME (macOS는 ~/Library/Application Support) 아래 sb-backup-creator 폴더
+func resolveConfigPath() (string, error) {
+	if configFlag != "" {
+		return filepath.Abs(configFlag)
+	}
+	if isPortable() {
+		dir, err := exeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, configFileName), nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("설정 폴더를 찾을 수 없습니다: %v", err)
+	}
+	return filepath.Join(dir, appDirName, configFileName), nil
+}
+
+// resolveStateDir ipc.json, 로그 파일, 백업 카탈로그를 둘 폴더
+// 포터블 모드면 실행 파일 옆, 아니면 Windows는 %LOCALAPPDATA%, Linux는 $XDG_STATE_HOME (~/.local/state), macOS는 설정과 같은 폴더
+func resolveStateDir() (string, error) {
+	if isPortable() {
+		return exeDir()
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LOCALAPPDATA"); dir != "" {
+			return filepath.Join(dir, appDirName), nil
+		}
+	case "darwin":
+	default:
+		if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+			return filepath.Join(dir, appDirName), nil
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".local", "state", appDirName), nil
+		}
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("상태 폴더를 찾을 수 없습니다: %v", err)
+	}
+	return filepath.Join(dir, appDirName), nil
+}
+
+// stateFilePath 상태 폴더 안의 파일 경로 (name에 하위 폴더가 있으면 그 폴더까지, 없으면 만듦)
+func stateFilePath(name string) (string, error) {
+	dir, err := resolveStateDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("상태 폴더 생성 실패: %v", err)
+	}
+	return path, nil
+}
+
+// openLogFile 트레이 모드의 로그를 상태 폴더의 로그 파일에도 기록 (-H windowsgui로 빌드하면 콘솔이 없으므로)
+func openLogFile() {
+	path, err := stateFilePath(logFileName)
+	if err != nil {
+		log.Printf("로그 파일을 열 수 없습니다: %v", err)
+		return
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > maxLogFileSize {
+		os.Rename(path, path+".old")
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		log.Printf("로그 파일을 열 수 없습니다: %v", err)
+		return
+	}
+	log.SetOutput(io.MultiWriter(os.Stderr, file))
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ludusavi 매니페스트 가져오기
+// https://github.com/mtkennerly/ludusavi-manifest 의 manifest.yaml 로컬 사본을 읽어
+// 설치된 Steam 게임의 세이브 위치를 찾고 게임마다 프로필 생성
+
+// 게임 하나에서 가져올 최대 세이브 파일 수 (나머지는 무시)
+const maxManifestTargets = 32
+
+// ludusaviGame 매니페스트의 게임 항목 (세이브 위치에 필요한 부분만)
+type ludusaviGame struct {
+	Files      map[string]ludusaviFile `yaml:"files"`
+	InstallDir map[string]any          `yaml:"installDir"`
+	Launch     map[string]any          `yaml:"launch"`
+	Steam      struct {
+		ID int `yaml:"id"`
+	} `yaml:"steam"`
+}
+
+type ludusaviFile struct {
+	Tags []string       `yaml:"tags"`
+	When []ludusaviWhen `yaml:"when"`
+}
+
+type ludusaviWhen struct {
+	OS    string `yaml:"os"`
+	Store string `yaml:"store"`
+}
+
+// steamGame steamapps/appmanifest_*.acf에 기록된 설치된 게임
+type steamGame struct {
+	AppID      string
+	Name       string
+	InstallDir string
+	Library    string
+}
+
+// manifestMatch 설치된 게임과 찾은 세이브 파일
+type manifestMatch struct {
+	Name      string
+	Game      steamGame
+	Files     []string
+	Processes []string
+}
+
+var acfFieldPattern = regexp.MustCompile(`"(appid|name|installdir)"\s+"([^"]*)"`)
+
+// loadLudusaviManifest 매니페스트 YAML 읽기
+func loadLudusaviManifest(path string) (map[string]ludusaviGame, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("매니페스트 읽기 실패: %v", err)
+	}
+
+	var manifest map[string]ludusaviGame
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("매니페스트 파싱 실패: %v", err)
+	}
+	return manifest, nil
+}
+
+// installedSteamGames 모든 Steam 라이브러리에 설치된 게임
+func installedSteamGames() []steamGame {
+	var games []steamGame
+	seen := make(map[string]bool)
+	for _, library := range steamLibraries() {
+		manifests, _ := filepath.Glob(filepath.Join(library, "steamapps", "appmanifest_*.acf"))
+		for _, path := range manifests {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+
+			game := steamGame{Library: library}
+			for _, match := range acfFieldPattern.FindAllStringSubmatch(string(data), -1) {
+				switch match[1] {
+				case "appid":
+					game.AppID = match[2]
+				case "name":
+					game.Name = match[2]
+				case "installdir":
+					game.InstallDir = match[2]
+				}
+			}
+			if game.AppID == "" || seen[game.AppID] {
+				continue
+			}
+			seen[game.AppID] = true
+			games = append(games, game)
+		}
+	}
+	return games
+}
+
+// findManifestGames 매니페스트에 있는 설치된 Steam 게임과 세이브 파일 (게임 이름 순)
+func findManifestGames(manifest map[string]ludusaviGame) []manifestMatch {
+	byAppID := make(map[string]string)
+	for name, game := range manifest {
+		if game.Steam.ID != 0 {
+			byAppID[strconv.Itoa(game.Steam.ID)] = name
+		}
+	}
+
+	var matches []manifestMatch
+	for _, installed := range installedSteamGames() {
+		name, ok := byAppID[installed.AppID]
+		if !ok {
+			continue
+		}
+		game := manifest[name]
+		matches = append(matches, manifestMatch{
+			Name:      name,
+			Game:      installed,
+			Files:     resolveManifestFiles(game, installed),
+			Processes: manifestProcesses(game),
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return strings.ToLower(matches[i].Name) < strings.ToLower(matches[j].Name)
+	})
+	return matches
+}
+
+// resolveManifestFiles 게임의 세이브 경로를 이 PC 자체와 (Linux면) 게임의 Proton 접두사에서 찾기
+// save 태그가 없는 항목(설정 파일 등)은 제외
+func resolveManifestFiles(game ludusaviGame, installed steamGame) []string {
+	gameVars := map[string]string{
+		"<root>":        installed.Library,
+		"<game>":        installed.InstallDir,
+		"<base>":        filepath.Join(installed.Library, "steamapps", "common", installed.InstallDir),
+		"<storeGameId>": installed.AppID,
+	}
+	contexts := []pathContext{nativeContext().with(gameVars)}
+	if runtime.GOOS == "linux" {
+		for _, ctx := range protonContexts(installed.AppID) {
+			contexts = append(contexts, ctx.with(gameVars))
+		}
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	for pattern, entry := range game.Files {
+		if len(entry.Tags) > 0 && !containsString(entry.Tags, "save") {
+			continue
+		}
+		for _, ctx := range contexts {
+			if !entry.appliesTo(ctx) {
+				continue
+			}
+			for _, match := range ctx.glob(pattern) {
+				for _, file := range regularFiles(match) {
+					if !seen[file] {
+						seen[file] = true
+						files = append(files, file)
+					}
+				}
+			}
+		}
+	}
+	return files
+}
+
+// appliesTo when 조건(운영체제, 스토어)이 환경에 맞는지. 조건이 없으면 항상 적용
+func (f ludusaviFile) appliesTo(ctx pathContext) bool {
+	if len(f.When) == 0 {
+		return true
+	}
+
+	osName := runtime.GOOS
+	if ctx.windows {
+		osName = "windows"
+	} else if osName == "darwin" {
+		osName = "mac"
+	}
+	for _, when := range f.When {
+		if (when.OS == "" || when.OS == osName) && (when.Store == "" || when.Store == "steam") {
+			return true
+		}
+	}
+	return false
+}
+
+// regularFiles 파일이면 그 파일, 폴더면 하위의 모든 파일
+func regularFiles(path string) []string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		return []string{path}
+	}
+
+	var files []string
+	filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			files = append(files, file)
+		}
+		return nil
+	})
+	return files
+}
+
+// manifestProcesses 매니페스트 launch 항목의 실행 파일 이름 (게임 세션 감지용)
+func manifestProcesses(game ludusaviGame) []string {
+	processes := []string{}
+	for path := range game.Launch {
+		name := filepath.Base(filepath.FromSlash(path))
+		if strings.HasPrefix(name, "<") || containsString(processes, name) {
+			continue
+		}
+		processes = append(processes, name)
+	}
+	sort.Strings(processes)
+	return processes
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// profileFromManifest 찾은 세이브로 프로필 만들기
+// 가장 최근에 바뀐 파일이 target_file, 나머지는 extra_targets. 같은 이름의 파일은 백업 이름이 겹치므로 최근 것만 사용
+func profileFromManifest(match manifestMatch, backupRoot string, maxBackups int) (Profile, []string) {
+	files := append([]string(nil), match.Files...)
+	modTimes := make(map[string]int64, len(files))
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			modTimes[file] = info.ModTime().UnixNano()
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return modTimes[files[i]] > modTimes[files[j]]
+	})
+
+	profile := Profile{
+		Name:          match.Name,
+		BackupDir:     filepath.Join(backupRoot, safeFileName(match.Name)),
+		MaxBackups:    maxBackups,
+		GameProcesses: match.Processes,
+		SteamAppID:    match.Game.AppID,
+	}
+
+	var skipped []string
+	names := make(map[string]bool)
+	for _, file := range files {
+		name := strings.ToLower(filepath.Base(file))
+		if names[name] || len(names) >= maxManifestTargets {
+			skipped = append(skipped, file)
+			continue
+		}
+		names[name] = true
+		if profile.TargetFile == "" {
+			profile.TargetFile = file
+		} else {
+			profile.ExtraTargets = append(profile.ExtraTargets, file)
+		}
+	}
+	return profile, skipped
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]+`)
+
+// safeFileName 게임 이름을 폴더 이름으로 쓸 수 있게 바꾸기
+func safeFileName(name string) string {
+	name = strings.TrimRight(strings.TrimSpace(unsafeFileNameChars.ReplaceAllString(name, "_")), ". ")
+	if name == "" {
+		return "game"
+	}
+	return name
+}
+
+// importManifest 매니페스트에서 설치된 게임을 찾아 프로필로 추가
+// 이미 같은 이름의 프로필이 있거나 다른 프로필이 감시하는 세이브면 건너뜀
+func importManifest(manifestPath string, dryRun bool) int {
+	if err := initializeConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "설정 초기화 실패: %v\n", err)
+		return 1
+	}
+
+	manifest, err := loadLudusaviManifest(manifestPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	cfg := GetConfig().clone()
+
+	watched := make(map[string]bool)
+	for _, target := range watchTargets() {
+		watched[filepath.Clean(target.TargetFile)] = true
+	}
+
+	backupRoot := cfg.BackupDir
+	added := 0
+	for _, match := range findManifestGames(manifest) {
+		switch {
+		case len(match.Files) == 0:
+			fmt.Printf("- %s: 세이브 파일을 찾지 못했습니다\n", match.Name)
+			continue
+		case findProfile(match.Name) != nil:
+			fmt.Printf("- %s: 같은 이름의 프로필이 이미 있습니다\n", match.Name)
+			continue
+		case anyWatched(match.Files, watched):
+			fmt.Printf("- %s: 이미 다른 프로필이 감시하는 세이브입니다\n", match.Name)
+			continue
+		}
+
+		profile, skipped := profileFromManifest(match, backupRoot, cfg.MaxBackups)
+		cfg.Profiles = append(cfg.Profiles, profile)
+		added++
+
+		fmt.Printf("+ %s (앱 %s): 세이브 %d개 → %s\n", profile.Name, match.Game.AppID, 1+len(profile.ExtraTargets), profile.BackupDir)
+		fmt.Printf("    %s\n", profile.TargetFile)
+		for _, extra := range profile.ExtraTargets {
+			fmt.Printf("    %s\n", extra)
+		}
+		if len(skipped) > 0 {
+			fmt.Printf("    (이름이 겹치거나 %d개를 넘어 제외한 파일 %d개)\n", maxManifestTargets, len(skipped))
+		}
+	}
+
+	if added == 0 {
+		fmt.Println("추가할 게임이 없습니다")
+		return 0
+	}
+	if errs := validateSettings(cfg); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return 1
+	}
+	if dryRun {
+		fmt.Printf("프로필 %d개를 추가할 수 있습니다 (--dry-run, 저장하지 않음)\n", added)
+		return 0
+	}
+
+	if err := saveConfig(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("프로필 %d개를 추가했습니다: %s\n", added, configPath)
+
+	// 트레이 인스턴스가 실행 중이면 바로 적용
+	var result map[string]string
+	if err := queryIPC("config reload", &result); err == nil && result["error"] != "" {
+		fmt.Fprintf(os.Stderr, "실행 중인 SB Backup Creator에 적용하지 못했습니다: %s\n", result["error"])
+	}
+	return 0
+}
+
+func anyWatched(files []string, watched map[string]bool) bool {
+	for _, file := range files {
+		if watched[filepath.Clean(file)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,488 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ludusavi 백업 형식 내보내기/가져오기
+// Ludusavi 백업 폴더: <루트>/<게임 이름>/mapping.yaml 과 <백업 이름>/drive-C/Users/... 처럼 드라이브별로 원래 경로를 따른 파일
+// 내보내기는 이 도구의 백업 하나(같은 시각에 만든 여러 세이브는 하나로 묶음)를 Ludusavi 전체 백업 하나로 기록
+// 가져오기는 Ludusavi 백업의 파일을 프로필의 세이브에 맞춰 이 도구의 백업으로 복사하고 카탈로그에 생성 시각과 원래 경로 기록
+
+const ludusaviMappingName = "mapping.yaml"
+
+// ludusaviMapping mapping.yaml
+type ludusaviMapping struct {
+	Name    string            `yaml:"name"`
+	Drives  map[string]string `yaml:"drives"`
+	Backups []ludusaviBackup  `yaml:"backups"`
+}
+
+// ludusaviBackup 전체 백업 하나 (Children은 차등 백업)
+type ludusaviBackup struct {
+	Name     string                          `yaml:"name"`
+	When     time.Time                       `yaml:"when"`
+	OS       string                          `yaml:"os,omitempty"`
+	Comment  string                          `yaml:"comment,omitempty"`
+	Locked   bool                            `yaml:"locked,omitempty"`
+	Files    map[string]*ludusaviMappingFile `yaml:"files"`
+	Registry map[string]any                  `yaml:"registry,omitempty"`
+	Children []ludusaviBackup                `yaml:"children,omitempty"`
+}
+
+// ludusaviMappingFile 백업한 파일의 해시와 크기 (차등 백업에서 nil이면 삭제된 파일)
+type ludusaviMappingFile struct {
+	Hash string `yaml:"hash"`
+	Size int64  `yaml:"size"`
+}
+
+// loadLudusaviMapping 게임 폴더의 mapping.yaml 읽기 (없으면 새 매핑)
+func loadLudusaviMapping(gameDir, name string) (*ludusaviMapping, error) {
+	mapping := &ludusaviMapping{Name: name, Drives: make(map[string]string)}
+
+	data, err := os.ReadFile(filepath.Join(gameDir, ludusaviMappingName))
+	if os.IsNotExist(err) {
+		return mapping, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s 읽기 실패: %v", ludusaviMappingName, err)
+	}
+	if err := yaml.Unmarshal(data, mapping); err != nil {
+		return nil, fmt.Errorf("%s 파싱 실패: %v", ludusaviMappingName, err)
+	}
+	if mapping.Drives == nil {
+		mapping.Drives = make(map[string]string)
+	}
+	return mapping, nil
+}
+
+func saveLudusaviMapping(gameDir string, mapping *ludusaviMapping) error {
+	data, err := yaml.Marshal(mapping)
+	if err != nil {
+		return fmt.Errorf("%s 생성 실패: %v", ludusaviMappingName, err)
+	}
+	if err := os.WriteFile(filepath.Join(gameDir, ludusaviMappingName), data, 0644); err != nil {
+		return fmt.Errorf("%s 저장 실패: %v", ludusaviMappingName, err)
+	}
+	return nil
+}
+
+// ludusaviPath 로컬 경로를 mapping.yaml 경로("C:/Users/..." 또는 "/home/...")와 드라이브 폴더로 변환
+func ludusaviPath(path string) (mapped, driveFolder, driveValue, rel string, err error) {
+	path = filepath.ToSlash(filepath.Clean(path))
+	switch {
+	case len(path) >= 3 && path[1] == ':' && path[2] == '/':
+		letter := strings.ToUpper(path[:1])
+		return letter + path[1:], "drive-" + letter, letter + ":", path[3:], nil
+	case strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "//"):
+		return path, "drive-0", "", path[1:], nil
+	default:
+		return "", "", "", "", fmt.Errorf("Ludusavi 형식으로 바꿀 수 없는 경로입니다: %s", path)
+	}
+}
+
+func ludusaviOS() string {
+	if runtime.GOOS == "darwin" {
+		return "mac"
+	}
+	return runtime.GOOS
+}
+
+// exportBackup 내보낼 백업 묶음 (같은 시각에 만든 프로필의 세이브 백업들)
+type exportBackup struct {
+	key     string // 묶음 기준 (날짜 이름 또는 백업 파일 경로)
+	created time.Time
+	label   string
+	files   []exportFile
+}
+
+type exportFile struct {
+	backup     string    // 이 도구의 백업 파일
+	targetFile string    // 원래 세이브 경로
+	modTime    time.Time // 백업 당시 세이브 수정 시각
+}
+
+// profileExportBackups 프로필의 백업을 Ludusavi 백업 단위로 묶기 (오래된 것부터)
+// 날짜 이름 백업은 같은 시각 이름끼리 한 묶음 (여러 계정의 세이브도 경로가 달라 한 묶음에 들어감), 자동/퀵 슬롯 백업은 각각 한 묶음
+func profileExportBackups(p *Profile) []exportBackup {
+	groups := make(map[string]*exportBackup)
+	for _, target := range p.targets() {
+		backups, err := listTargetBackups(target)
+		if err != nil {
+			continue
+		}
+		for _, backup := range backups {
+			rest := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(backup.Path), target.Prefix+"_"), filepath.Ext(target.TargetFile))
+			key := backup.Path
+			if rest != "" && rest[0] >= '0' && rest[0] <= '9' {
+				key = rest
+			}
+
+			group, ok := groups[key]
+			if !ok {
+				group = &exportBackup{key: key, created: backup.Created, label: backup.Label}
+				groups[key] = group
+			}
+			if backup.Created.Before(group.created) {
+				group.created = backup.Created
+			}
+
+			modTime := backup.SourceModTime
+			if modTime.IsZero() {
+				modTime = backup.ModTime
+			}
+			group.files = append(group.files, exportFile{backup: backup.Path, targetFile: target.TargetFile, modTime: modTime})
+		}
+	}
+
+	list := make([]exportBackup, 0, len(groups))
+	for _, group := range groups {
+		list = append(list, *group)
+	}
+	// 다시 내보낼 때 같은 이름이 붙도록 같은 시각이면 묶음 기준으로 정렬
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].created.Equal(list[j].created) {
+			return list[i].created.Before(list[j].created)
+		}
+		return list[i].key < list[j].key
+	})
+	return list
+}
+
+// exportLudusavi 프로필의 백업을 Ludusavi 백업 폴더(root/<게임 이름>)로 내보내기
+// 같은 초에 만든 묶음은 이름 뒤에 -2, -3을 붙이고, 이미 있는 mapping.yaml의 같은 이름 백업은 이전에 내보낸 것으로 보고 건너뜀
+func exportLudusavi(p *Profile, root string) (exported, skipped int, err error) {
+	gameDir := filepath.Join(root, safeFileName(p.Name))
+	if err := os.MkdirAll(gameDir, 0755); err != nil {
+		return 0, 0, fmt.Errorf("내보낼 폴더 생성 실패: %v", err)
+	}
+
+	mapping, err := loadLudusaviMapping(gameDir, p.Name)
+	if err != nil {
+		return 0, 0, err
+	}
+	existing := make(map[string]bool)
+	for _, backup := range mapping.Backups {
+		existing[backup.Name] = true
+	}
+
+	named := make(map[string]bool)
+	for _, group := range profileExportBackups(p) {
+		base := "backup-" + group.created.UTC().Format("20060102T150405Z")
+		name := base
+		for n := 2; named[name]; n++ {
+			name = fmt.Sprintf("%s-%d", base, n)
+		}
+		named[name] = true
+		if existing[name] {
+			skipped++
+			continue
+		}
+
+		backup := ludusaviBackup{
+			Name:    name,
+			When:    group.created.UTC(),
+			OS:      ludusaviOS(),
+			Comment: group.label,
+			Files:   make(map[string]*ludusaviMappingFile),
+		}
+		for _, file := range group.files {
+			mapped, driveFolder, driveValue, rel, err := ludusaviPath(file.targetFile)
+			if err != nil {
+				return exported, skipped, err
+			}
+			dst := filepath.Join(gameDir, name, driveFolder, filepath.FromSlash(rel))
+			if err := copyFile(file.backup, dst); err != nil {
+				return exported, skipped, err
+			}
+			if err := os.Chtimes(dst, file.modTime, file.modTime); err != nil {
+				return exported, skipped, fmt.Errorf("파일 시각 설정 실패: %v", err)
+			}
+			hash, size, err := hashFile(dst)
+			if err != nil {
+				return exported, skipped, err
+			}
+
+			mapping.Drives[driveFolder] = driveValue
+			backup.Files[mapped] = &ludusaviMappingFile{Hash: hash, Size: size}
+		}
+
+		mapping.Backups = append(mapping.Backups, backup)
+		exported++
+	}
+
+	if exported == 0 {
+		return 0, skipped, nil
+	}
+	return exported, skipped, saveLudusaviMapping(gameDir, mapping)
+}
+
+// hashFile Ludusavi가 쓰는 SHA-1 해시와 크기
+func hashFile(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("파일 열기 실패: %v", err)
+	}
+	defer file.Close()
+
+	hash := sha1.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("파일 읽기 실패: %v", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// ludusaviGameDirs 가져올 폴더가 게임 폴더(mapping.yaml 있음)면 그 폴더, 아니면 하위의 게임 폴더 목록
+func ludusaviGameDirs(dir string) []string {
+	if _, err := os.Stat(filepath.Join(dir, ludusaviMappingName)); err == nil {
+		return []string{dir}
+	}
+
+	var dirs []string
+	matches, _ := filepath.Glob(filepath.Join(dir, "*", ludusaviMappingName))
+	for _, match := range matches {
+		dirs = append(dirs, filepath.Dir(match))
+	}
+	return dirs
+}
+
+// ludusaviSourceFile mapping.yaml 경로에 해당하는 백업 폴더 안의 파일
+func ludusaviSourceFile(mapping *ludusaviMapping, backupDir, mapped string) (string, bool) {
+	for folder, value := range mapping.Drives {
+		if value == "" && strings.HasPrefix(mapped, "/") {
+			return filepath.Join(backupDir, folder, filepath.FromSlash(mapped[1:])), true
+		}
+		if value != "" && strings.HasPrefix(strings.ToUpper(mapped), strings.ToUpper(value)+"/") {
+			return filepath.Join(backupDir, folder, filepath.FromSlash(mapped[len(value)+1:])), true
+		}
+	}
+	return "", false
+}
+
+// importFile 가져올 파일 하나
+type importFile struct {
+	mapped string // mapping.yaml의 원래 경로
+	path   string // Ludusavi 백업 폴더 안의 파일
+}
+
+// ludusaviBackupFiles 백업 하나에 들어 있는 파일 목록
+// 차등 백업은 바뀐 파일만 자기 폴더에 있으므로 그 파일만 가져옴 (나머지는 전체 백업과 같은 내용)
+func ludusaviBackupFiles(mapping *ludusaviMapping, gameDir string, backup *ludusaviBackup) []importFile {
+	var files []importFile
+	for mapped, file := range backup.Files {
+		if file == nil {
+			continue
+		}
+		path, ok := ludusaviSourceFile(mapping, filepath.Join(gameDir, backup.Name), mapped)
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		files = append(files, importFile{mapped: mapped, path: path})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].mapped < files[j].mapped
+	})
+	return files
+}
+
+// importTarget Ludusavi 백업 파일이 프로필의 어느 세이브인지 (같은 경로, 없으면 같은 파일 이름)
+func importTarget(p *Profile, mapped string) (saveTarget, bool) {
+	local := filepath.Clean(filepath.FromSlash(mapped))
+	targets := p.targets()
+	for _, target := range targets {
+		if strings.EqualFold(filepath.Clean(target.TargetFile), local) {
+			return target, true
+		}
+	}
+	name := filepath.Base(local)
+	for _, target := range targets {
+		if strings.EqualFold(filepath.Base(target.TargetFile), name) {
+			return target, true
+		}
+	}
+	return saveTarget{}, false
+}
+
+// importLudusavi Ludusavi 게임 폴더 하나의 백업을 프로필의 백업으로 가져오기
+// 이미 가져온 백업(같은 원래 경로와 생성 시각)은 건너뜀. 반환값은 가져온 파일 수와 건너뛴 파일 설명
+func importLudusavi(p *Profile, gameDir string) (int, []string, error) {
+	mapping, err := loadLudusaviMapping(gameDir, p.Name)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	imported := 0
+	var skipped []string
+	importBackup := func(backup ludusaviBackup, files []importFile) error {
+		for _, file := range files {
+			target, ok := importTarget(p, file.mapped)
+			if !ok {
+				skipped = append(skipped, fmt.Sprintf("%s: %s 프로필에 맞는 세이브가 없습니다", file.mapped, p.Name))
+				continue
+			}
+			if alreadyImported(target, file.mapped, backup.When) {
+				continue
+			}
+			if err := importBackupFile(target, file, backup); err != nil {
+				return err
+			}
+			imported++
+		}
+		return nil
+	}
+
+	for i := range mapping.Backups {
+		full := &mapping.Backups[i]
+		if err := importBackup(*full, ludusaviBackupFiles(mapping, gameDir, full)); err != nil {
+			return imported, skipped, err
+		}
+		for j := range full.Children {
+			diff := &full.Children[j]
+			if err := importBackup(*diff, ludusaviBackupFiles(mapping, gameDir, diff)); err != nil {
+				return imported, skipped, err
+			}
+		}
+	}
+	return imported, skipped, nil
+}
+
+// alreadyImported 카탈로그에 같은 원래 경로, 같은 생성 시각으로 가져온 기록이 있는지
+func alreadyImported(target saveTarget, mapped string, when time.Time) bool {
+	for _, entry := range readCatalog(target.BackupDir) {
+		if entry.Trigger == triggerImport && entry.Source == mapped && entry.Created.Equal(when) {
+			return true
+		}
+	}
+	return false
+}
+
+// importBackupFile 파일을 백업 생성 시각 이름으로 복사하고 카탈로그에 기록
+// 백업 파일 수정 시각은 생성 시각(최근 백업 정렬 기준), 원래 파일 수정 시각은 source_mod_time으로 보존
+func importBackupFile(target saveTarget, file importFile, backup ludusaviBackup) error {
+	info, err := os.Stat(file.path)
+	if err != nil {
+		return fmt.Errorf("Ludusavi 백업 파일을 찾을 수 없습니다: %v", err)
+	}
+
+	when := backup.When.Local()
+	backupPath := uniqueBackupPath(target.backupPath(when.Format("20060102_150405")))
+	if err := copyFile(file.path, backupPath); err != nil {
+		return err
+	}
+	if err := os.Chtimes(backupPath, when, when); err != nil {
+		return fmt.Errorf("파일 시각 설정 실패: %v", err)
+	}
+
+	name := filepath.Base(backupPath)
+	updateCatalog(target.BackupDir, func(entries map[string]catalogEntry) {
+		entries[name] = catalogEntry{
+			File:    name,
+			Created: backup.When,
+			Trigger: triggerImport,
+			Label:   backup.Comment,
+			Pinned:  backup.Locked,
+			Account: target.Account,
+			Target:  target.TargetFile,
+			Source:  file.mapped,
+
+			SourceModTime: info.ModTime(),
+		}
+	})
+	return nil
+}
+
+// runLudusaviExport export 명령: 프로필(지정하지 않으면 모든 프로필)의 백업을 Ludusavi 형식으로 내보내기
+func runLudusaviExport(profileName, root string) int {
+	if err := initializeConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "설정 초기화 실패: %v\n", err)
+		return 1
+	}
+
+	profiles := allProfiles()
+	if profileName != "" {
+		p := findProfile(profileName)
+		if p == nil {
+			fmt.Fprintf(os.Stderr, "프로필을 찾을 수 없습니다: %s\n", profileName)
+			return 1
+		}
+		profiles = []*Profile{p}
+	}
+
+	exitCode := 0
+	for _, p := range profiles {
+		count, skipped, err := exportLudusavi(p, root)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", p.Name, err)
+			exitCode = 1
+		}
+		fmt.Printf("%s: 백업 %d개 내보냄 → %s\n", p.Name, count, filepath.Join(root, safeFileName(p.Name)))
+		if skipped > 0 {
+			fmt.Printf("%s: 이미 내보낸 백업 %d개는 건너뜀\n", p.Name, skipped)
+		}
+	}
+	return exitCode
+}
+
+// runLudusaviImport import 명령: Ludusavi 백업 폴더의 게임을 이름이 같은 프로필(또는 지정한 프로필)로 가져오기
+func runLudusaviImport(profileName, dir string) int {
+	if err := initializeConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "설정 초기화 실패: %v\n", err)
+		return 1
+	}
+
+	gameDirs := ludusaviGameDirs(dir)
+	if len(gameDirs) == 0 {
+		fmt.Fprintf(os.Stderr, "Ludusavi 백업(%s)을 찾을 수 없습니다: %s\n", ludusaviMappingName, dir)
+		return 1
+	}
+	if profileName != "" && len(gameDirs) > 1 {
+		fmt.Fprintln(os.Stderr, "--profile은 게임 폴더 하나를 가져올 때만 사용할 수 있습니다")
+		return 2
+	}
+
+	exitCode := 0
+	for _, gameDir := range gameDirs {
+		mapping, err := loadLudusaviMapping(gameDir, "")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", gameDir, err)
+			exitCode = 1
+			continue
+		}
+
+		name := profileName
+		if name == "" {
+			name = mapping.Name
+		}
+		p := findProfile(name)
+		if p == nil {
+			fmt.Printf("- %s: 같은 이름의 프로필이 없어 건너뜁니다 (--profile로 지정)\n", mapping.Name)
+			continue
+		}
+
+		count, skipped, err := importLudusavi(p, gameDir)
+		for _, message := range skipped {
+			fmt.Printf("    %s\n", message)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", mapping.Name, err)
+			exitCode = 1
+		}
+		fmt.Printf("%s → %s: 백업 파일 %d개 가져옴\n", mapping.Name, p.Name, count)
+	}
+	return exitCode
+}
@@ -1,14 +1,31 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/getlantern/systray"
-	"github.com/getlantern/systray/example/icon"
 	"golang.design/x/hotkey/mainthread"
 )
 
 func main() {
+	// --config 등 모든 명령에 공통인 옵션
+	args, err := parseGlobalOptions(os.Args[1:])
+	if err != nil {
+		attachParentConsole()
+		fmt.Fprintf(os.Stderr, "%v\n%s\n", err, usageText)
+		os.Exit(2)
+	}
+
+	// 명령줄 하위 명령 (run 등)은 트레이 없이 실행
+	if handled, exitCode := runCommand(args); handled {
+		os.Exit(exitCode)
+	}
+
+	// 트레이 모드의 로그는 상태 폴더의 로그 파일에도 기록
+	openLogFile()
+
 	// 단일 인스턴스 확인
 	if !ensureSingleInstance() {
 		return // 이미 실행 중이면 종료
@@ -26,29 +43,55 @@ func main() {
 
 func onReady() {
 	// 트레이 아이콘 설정
-	systray.SetIcon(icon.Data)
+	systray.SetIcon(iconIdle)
 	systray.SetTitle("SB Backup Creator")
-	systray.SetTooltip("Stellar Blade Save Backup Tool")
+	systray.SetTooltip(trayTooltip)
 
 	// 설정 초기화
 	if err := initializeConfig(); err != nil {
 		log.Printf("설정 초기화 실패: %v", err)
+		showConfigProblems([]error{err})
 		return
 	}
+	if errs := checkConfigFile(); len(errs) > 0 {
+		showConfigProblems(errs)
+	}
+
+	// 새 설정이 게시되면 (설정 창, 마법사, 설정 파일 편집, 명령줄) 실행 중인 기능에 반영
+	onConfigChange(applyConfigChanges)
+
+	if firstRun {
+		showSetupWizard()
+	}
 
 	// 파일 감시 시작
 	go startFileWatcher()
 
+	// 설정 파일을 고치면 바로 적용
+	startConfigWatcher()
+
+	// 게임 실행 감지 시작
+	startProcessMonitor()
+
 	// 단축키 등록
 	go registerHotkeys()
 
+	// 명령줄 하위 명령(hotkeys status 등) 요청 대기
+	startIPCServer()
+
 	// 메뉴 아이템 생성
+	setupStatusMenu()
+	systray.AddSeparator()
 	mBackupNow := systray.AddMenuItem("지금 백업", "수동 백업 실행")
+	setupRecentBackupsMenu()
+	setupProfilesMenu()
 	mOpenBackup := systray.AddMenuItem("백업 폴더 열기", "백업 파일들이 저장된 폴더 열기")
+	setupHotkeyMenu()
+	systray.AddSeparator()
+	setupAutoBackupMenu()
 	systray.AddSeparator()
-	// mSettings := systray.AddMenuItem("설정", "설정 변경")
-	// mConfigFile := systray.AddMenuItem("설정 파일 편집", "settings.json 파일 직접 편집")
-	mConfigFile := systray.AddMenuItem("설정 편집", "settings.json 파일 직접 편집")
+	mSettings := systray.AddMenuItem("설정", "설정 변경")
+	mConfigFile := systray.AddMenuItem("설정 파일 편집", "settings.json 파일 직접 편집")
 	systray.AddSeparator()
 	// mAbout := systray.AddMenuItem("정보", "프로그램 정보")
 	mExit := systray.AddMenuItem("종료", "프로그램 종료")
@@ -60,9 +103,9 @@ func onReady() {
 			case <-mBackupNow.ClickedCh:
 				performManualBackup()
 			case <-mOpenBackup.ClickedCh:
-				openBackupFolder()
-			// case <-mSettings.ClickedCh:
-			// 	showSettingsDialog()
+				openBackupFolder(defaultProfile())
+			case <-mSettings.ClickedCh:
+				showSettingsDialog()
 			case <-mConfigFile.ClickedCh:
 				openConfigFile()
 			// case <-mAbout.ClickedCh:
@@ -85,6 +128,9 @@ func hideConsole() {
 }
 
 func cleanup() {
+	stopConfigWatcher()
 	stopFileWatcher()
+	stopProcessMonitor()
+	stopIPCServer()
 	unregisterHotkeys()
 }
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 설정 파일 버전과 변환
+// settings.json의 version이 configVersion보다 낮으면 configMigrations를 차례로 적용해서 올리고,
+// 변환 전 파일은 settings.json.v<이전 버전>.bak으로 남김
+
+// 현재 설정 파일 버전 (configMigrations 개수와 같아야 함)
+const configVersion = 2
+
+// configMigration 버전 하나를 올리는 변환. 알 수 없는 키도 유지하도록 JSON 객체 그대로 다룸
+type configMigration struct {
+	description string
+	apply       func(raw map[string]any) error
+}
+
+// configMigrations configMigrations[i]는 버전 i를 i+1로 변환
+var configMigrations = []configMigration{
+	{"version 항목 추가, 예전 기본 경로의 your_steam_id를 {steam_id}로 변경", migrateV0},
+	{"notifications.warning 추가 (failure와 같은 값)", migrateV1},
+}
+
+// 설정 파일 옆에 만드는 JSON Schema 파일 이름
+const configSchemaName = "settings.schema.json"
+
+func init() {
+	if len(configMigrations) != configVersion {
+		panic("configMigrations 개수와 configVersion이 다릅니다")
+	}
+}
+
+// migrateV0 version이 없던 설정 파일
+// 예전 기본 설정의 target_file에 있던 자리표시자를 경로 변수로 바꾸고 편집기용 $schema 추가
+func migrateV0(raw map[string]any) error {
+	replaceSteamID := func(object map[string]any) {
+		if path, ok := object["target_file"].(string); ok {
+			object["target_file"] = strings.ReplaceAll(path, "your_steam_id", "{steam_id}")
+		}
+		if extras, ok := object["extra_targets"].([]any); ok {
+			for i, extra := range extras {
+				if path, ok := extra.(string); ok {
+					extras[i] = strings.ReplaceAll(path, "your_steam_id", "{steam_id}")
+				}
+			}
+		}
+	}
+
+	replaceSteamID(raw)
+	if profiles, ok := raw["profiles"].([]any); ok {
+		for _, profile := range profiles {
+			if object, ok := profile.(map[string]any); ok {
+				replaceSteamID(object)
+			}
+		}
+	}
+
+	if _, ok := raw["$schema"]; !ok {
+		raw["$schema"] = "./" + configSchemaName
+	}
+	return nil
+}
+
+// migrateV1 경고 알림(notifications.warning)이 따로 생기기 전에는 대체 단축키 등록도 failure로 알렸으므로 같은 값으로 추가
+// notifications가 없으면 기본값을 쓰므로 그대로 둠
+func migrateV1(raw map[string]any) error {
+	notifications, ok := raw["notifications"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	if _, ok := notifications["warning"]; ok {
+		return nil
+	}
+	failure, ok := notifications["failure"].(bool)
+	notifications["warning"] = !ok || failure
+	return nil
+}
+
+// configDataVersion 설정 파일 내용의 version (없으면 0)
+func configDataVersion(raw map[string]any) (int, error) {
+	value, ok := raw["version"]
+	if !ok || value == nil {
+		return 0, nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("version은 숫자여야 합니다")
+	}
+	version, err := number.Int64()
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("잘못된 version: %s", number)
+	}
+	return int(version), nil
+}
+
+// migrateConfigData 설정 파일 내용을 현재 버전으로 변환. 이미 현재 버전이면 그대로 반환
+// from은 변환 전 버전. 더 새로운 버전의 파일은 그대로 쓰면 항목을 잃을 수 있으므로 오류
+func migrateConfigData(data []byte) (migrated []byte, from int, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(stripJSONC(data)))
+	decoder.UseNumber()
+	var raw map[string]any
+	if err := decoder.Decode(&raw); err != nil {
+		// 문법 오류는 decodeConfig에서 위치와 함께 표시
+		return data, configVersion, nil
+	}
+
+	from, err = configDataVersion(raw)
+	if err != nil {
+		return nil, 0, err
+	}
+	if from > configVersion {
+		return nil, from, fmt.Errorf("더 새로운 버전의 프로그램에서 만든 설정 파일입니다 (version %d, 지원하는 버전 %d)", from, configVersion)
+	}
+	if from == configVersion {
+		return data, from, nil
+	}
+
+	for version := from; version < configVersion; version++ {
+		migration := configMigrations[version]
+		if err := migration.apply(raw); err != nil {
+			return nil, from, fmt.Errorf("설정 파일 변환 실패 (version %d → %d): %v", version, version+1, err)
+		}
+		raw["version"] = version + 1
+	}
+
+	// 바뀐 값을 저장할 때와 같은 모양으로 쓰기 위해 구조체를 거침
+	plain, err := json.Marshal(raw)
+	if err != nil {
+		return nil, from, fmt.Errorf("설정 파일 변환 실패: %v", err)
+	}
+	cfg, err := decodeConfig(plain)
+	if err != nil {
+		return nil, from, fmt.Errorf("설정 파일 변환 실패: %v", err)
+	}
+	migrated, err = encodeConfig(cfg)
+	if err != nil {
+		return nil, from, err
+	}
+	// 사용자의 주석과 항목 순서는 유지
+	if patched, err := patchJSONC(data, migrated); err == nil {
+		migrated = patched
+	}
+	return migrated, from, nil
+}
+
+// migrateConfigFile 설정 파일이 예전 버전이면 변환해서 저장하고 이전 파일은 .bak으로 남김
+func migrateConfigFile(data []byte) ([]byte, error) {
+	migrated, from, err := migrateConfigData(data)
+	if err != nil {
+		return nil, err
+	}
+	if from == configVersion {
+		return data, nil
+	}
+
+	backupPath := uniqueBackupPath(fmt.Sprintf("%s.v%d.bak", configPath, from))
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return nil, fmt.Errorf("변환 전 설정 파일 백업 실패: %v", err)
+	}
+	for version := from; version < configVersion; version++ {
+		log.Printf("설정 파일 변환 (version %d → %d): %s", version, version+1, configMigrations[version].description)
+	}
+	rememberConfigData(migrated)
+	if err := os.WriteFile(configPath, migrated, 0644); err != nil {
+		return nil, fmt.Errorf("변환한 설정 파일 저장 실패: %v", err)
+	}
+	log.Printf("설정 파일을 version %d에서 %d로 변환했습니다. 이전 파일: %s", from, configVersion, filepath.Base(backupPath))
+	return migrated, nil
+}
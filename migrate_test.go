@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// migrationFixtures 변환 단계마다 적용해 볼 설정 파일 내용
+var migrationFixtures = []string{
+	`{}`,
+	`{"target_file": "C:/Users/me/AppData/Local/SB/Saved/SaveGames/your_steam_id/StellarBladeSave00.sav", "backup_dir": "D:/backups"}`,
+	`{"target_file": "a.sav", "extra_targets": ["your_steam_id/b.sav", 3], "profiles": [{"name": "B", "target_file": "your_steam_id/c.sav"}, "x"]}`,
+	`{"$schema": "custom.json", "version": 1}`,
+	`{"notifications": {"failure": false}}`,
+	`{"notifications": {"failure": true, "success": true}}`,
+	`{"notifications": {"warning": false, "failure": true}}`,
+	`{"notifications": {}}`,
+	`{"notifications": null, "unknown": {"nested": [1, 2]}}`,
+}
+
+func decodeRawConfig(t *testing.T, data string) map[string]any {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var raw map[string]any
+	if err := decoder.Decode(&raw); err != nil {
+		t.Fatalf("%s: %v", data, err)
+	}
+	return raw
+}
+
+// TestConfigMigrationsIdempotent 변환 단계를 두 번 적용해도 한 번 적용한 것과 같음
+func TestConfigMigrationsIdempotent(t *testing.T) {
+	for i, migration := range configMigrations {
+		for _, fixture := range migrationFixtures {
+			raw := decodeRawConfig(t, fixture)
+			if err := migration.apply(raw); err != nil {
+				t.Errorf("migration %d on %s: %v", i, fixture, err)
+				continue
+			}
+			once, err := json.Marshal(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := migration.apply(raw); err != nil {
+				t.Errorf("migration %d twice on %s: %v", i, fixture, err)
+				continue
+			}
+			twice, err := json.Marshal(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(once, twice) {
+				t.Errorf("migration %d is not idempotent on %s:\nonce  %s\ntwice %s", i, fixture, once, twice)
+			}
+		}
+	}
+}
+
+func TestConfigMigrationSteps(t *testing.T) {
+	tests := []struct {
+		step    int
+		fixture string
+		want    string
+	}{
+		{0, `{}`, `{"$schema":"./settings.schema.json"}`},
+		{0, `{"$schema": "custom.json"}`, `{"$schema":"custom.json"}`},
+		{0, `{"$schema": "s", "target_file": "a/your_steam_id/b", "extra_targets": ["your_steam_id/c"]}`,
+			`{"$schema":"s","extra_targets":["{steam_id}/c"],"target_file":"a/{steam_id}/b"}`},
+		{0, `{"$schema": "s", "profiles": [{"target_file": "your_steam_id/x"}]}`,
+			`{"$schema":"s","profiles":[{"target_file":"{steam_id}/x"}]}`},
+		{1, `{}`, `{}`},
+		{1, `{"notifications": {}}`, `{"notifications":{"warning":true}}`},
+		{1, `{"notifications": {"failure": false}}`, `{"notifications":{"failure":false,"warning":false}}`},
+		{1, `{"notifications": {"failure": true}}`, `{"notifications":{"failure":true,"warning":true}}`},
+		{1, `{"notifications": {"failure": true, "warning": false}}`, `{"notifications":{"failure":true,"warning":false}}`},
+	}
+	for _, tt := range tests {
+		raw := decodeRawConfig(t, tt.fixture)
+		if err := configMigrations[tt.step].apply(raw); err != nil {
+			t.Errorf("migration %d on %s: %v", tt.step, tt.fixture, err)
+			continue
+		}
+		got, err := json.Marshal(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("migration %d on %s = %s, want %s", tt.step, tt.fixture, got, tt.want)
+		}
+	}
+}
+
+func TestMigrateConfigData(t *testing.T) {
+	original := "{\n" +
+		"  // 예전 설정\n" +
+		"  \"target_file\": \"C:/Saves/your_steam_id/StellarBladeSave00.sav\",\n" +
+		"  \"backup_dir\": \"D:/backups\", // 백업\n" +
+		"  \"notifications\": {\"failure\": false},\n" +
+		"  \"unknown_option\": 42\n" +
+		"}\n"
+
+	migrated, from, err := migrateConfigData([]byte(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != 0 {
+		t.Errorf("from = %d, want 0", from)
+	}
+	for _, comment := range []string{"// 예전 설정", "// 백업"} {
+		if !bytes.Contains(migrated, []byte(comment)) {
+			t.Errorf("comment %q lost:\n%s", comment, migrated)
+		}
+	}
+
+	raw := decodeRawConfig(t, string(stripJSONC(migrated)))
+	if version, err := configDataVersion(raw); err != nil || version != configVersion {
+		t.Errorf("version = %d (%v), want %d", version, err, configVersion)
+	}
+	checks := map[string]any{
+		"target_file":    "C:/Saves/{steam_id}/StellarBladeSave00.sav",
+		"$schema":        "./" + configSchemaName,
+		"unknown_option": json.Number("42"),
+	}
+	for key, want := range checks {
+		if raw[key] != want {
+			t.Errorf("%s = %v, want %v", key, raw[key], want)
+		}
+	}
+	if notifications, _ := raw["notifications"].(map[string]any); notifications["warning"] != false {
+		t.Errorf("notifications = %v, want warning false", raw["notifications"])
+	}
+
+	// 변환한 내용은 다시 변환하지 않음
+	again, from, err := migrateConfigData(migrated)
+	if err != nil || from != configVersion || !bytes.Equal(again, migrated) {
+		t.Errorf("second migration changed the file (from %d, %v):\n%s", from, err, again)
+	}
+}
+
+// TestMigrateConfigDataFromV1 중간 버전에서는 남은 단계만 적용
+func TestMigrateConfigDataFromV1(t *testing.T) {
+	original := `{"version": 1, "target_file": "your_steam_id/a.sav", "backup_dir": "b", "notifications": {"failure": true}}`
+	migrated, from, err := migrateConfigData([]byte(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != 1 {
+		t.Errorf("from = %d, want 1", from)
+	}
+	raw := decodeRawConfig(t, string(stripJSONC(migrated)))
+	if raw["target_file"] != "your_steam_id/a.sav" {
+		t.Errorf("target_file = %v, version 0 step must not run", raw["target_file"])
+	}
+	if _, ok := raw["$schema"]; ok {
+		t.Errorf("$schema added by version 0 step")
+	}
+	if notifications, _ := raw["notifications"].(map[string]any); notifications["warning"] != true {
+		t.Errorf("notifications = %v, want warning true", raw["notifications"])
+	}
+}
+
+func TestMigrateConfigDataUnchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		from int
+	}{
+		{"current version", "{\n  \"version\": 2, // 현재\n  \"max_backups\": 3\n}\n", configVersion},
+		{"syntax error", `{"version": 0, "max_backups": }`, configVersion}, // decodeConfig에서 위치와 함께 알림
+	}
+	for _, tt := range tests {
+		migrated, from, err := migrateConfigData([]byte(tt.data))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if from != tt.from || string(migrated) != tt.data {
+			t.Errorf("%s: got from %d\n%s", tt.name, from, migrated)
+		}
+	}
+}
+
+func TestMigrateConfigDataErrors(t *testing.T) {
+	tests := []string{
+		`{"version": 99}`,
+		`{"version": "2"}`,
+		`{"version": -1}`,
+		`{"version": 1.5}`,
+	}
+	for _, data := range tests {
+		if _, _, err := migrateConfigData([]byte(data)); err == nil {
+			t.Errorf("migrateConfigData(%s): expected error", data)
+		}
+	}
+}
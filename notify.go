@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// 알림 종류
+type notifyKind string
+
+const (
+	notifySuccess    notifyKind = "success"    // 백업 성공
+	notifyFailure    notifyKind = "failure"    // 백업/복원/단축키 등록 실패
+	notifyWarning    notifyKind = "warning"    // 동작은 하지만 알아 둘 일 (단축키 대체 조합으로 등록 등)
+	notifyQuarantine notifyKind = "quarantine" // 손상 의심 세이브 격리
+	notifyRollback   notifyKind = "rollback"   // 세이브가 이전 시점으로 되돌아감
+	notifyRestore    notifyKind = "restore"    // 복원 완료
+)
+
+// 같은 종류의 알림 사이 최소 간격 기본값
+const defaultNotifyInterval = 30 * time.Second
+
+var (
+	notifyMu         sync.Mutex
+	notifyLast       = make(map[notifyKind]time.Time)
+	notifySuppressed = make(map[notifyKind]int)
+)
+
+func notificationEnabled(kind notifyKind) bool {
+	cfg := GetConfig().Notifications
+	switch kind {
+	case notifySuccess:
+		return cfg.Success
+	case notifyFailure:
+		return cfg.Failure
+	case notifyWarning:
+		return cfg.Warning
+	case notifyQuarantine:
+		return cfg.Quarantine
+	case notifyRollback:
+		return cfg.Rollback
+	case notifyRestore:
+		return cfg.Restore
+	default:
+		return false
+	}
+}
+
+func notifyInterval() time.Duration {
+	if seconds := GetConfig().Notifications.MinIntervalSeconds; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultNotifyInterval
+}
+
+// notify 데스크톱 알림 표시 (트레이 앱은 콘솔이 없어 로그만으로는 알 수 없음)
+// 종류별로 켜고 끌 수 있고, 세이브가 연달아 바뀔 때 알림이 쏟아지지 않도록 종류별 간격을 둠
+func notify(kind notifyKind, title, message string) {
+	log.Printf("알림 (%s): %s - %s", kind, title, message)
+
+	if !notificationEnabled(kind) {
+		return
+	}
+
+	notifyMu.Lock()
+	if time.Since(notifyLast[kind]) < notifyInterval() {
+		notifySuppressed[kind]++
+		notifyMu.Unlock()
+		return
+	}
+	if suppressed := notifySuppressed[kind]; suppressed > 0 {
+		message = fmt.Sprintf("%s\n(그 사이 %d건 더 있음)", message, suppressed)
+	}
+	notifyLast[kind] = time.Now()
+	notifySuppressed[kind] = 0
+	notifyMu.Unlock()
+
+	go func() {
+		if err := showNotification(title, message); err != nil {
+			log.Printf("알림 표시 실패: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/godbus/dbus/v5"
+)
+
+// showNotification freedesktop org.freedesktop.Notifications D-Bus 인터페이스로 알림 표시
+func showNotification(title, message string) error {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	obj := conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+	call := obj.Call("org.freedesktop.Notifications.Notify", 0,
+		"SB Backup Creator",       // app_name
+		uint32(0),                 // replaces_id
+		"document-save",           // app_icon
+		title,                     // summary
+		message,                   // body
+		[]string{},                // actions
+		map[string]dbus.Variant{}, // hints
+		int32(-1),                 // expire_timeout (서버 기본값)
+	)
+	return call.Err
+}
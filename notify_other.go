@@ -0,0 +1,12 @@
+//go:build !windows && !linux
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func showNotification(title, message string) error {
+	return fmt.Errorf("데스크톱 알림을 지원하지 않는 운영체제입니다: %s", runtime.GOOS)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// 설정 파일 경로의 변수 치환
+// 환경 변수: %VAR%, $VAR, ${VAR} (Windows 형식 이름은 대문자로도 찾음: %localappdata% → LOCALAPPDATA)
+// 홈 폴더: 맨 앞의 ~
+// 내장 변수: {steam_id}, {steam_root}, {proton_prefix}, {exe_dir}, {xdg_data_home}
+// 설정 파일에는 치환 전 형태를 그대로 저장하고, 실행 중에는 치환한 경로를 사용
+
+var pathVarPattern = regexp.MustCompile(`%([A-Za-z0-9_()]+)%|\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)|\{([a-z_]+)\}`)
+
+// pathTokens 내장 변수 → 값 계산 (appID는 프로필의 Steam 앱 ID, 없으면 빈 문자열)
+var pathTokens = map[string]func(appID string) (string, bool){
+	"steam_id": func(string) (string, bool) {
+		id := activeSteamID()
+		return id, id != ""
+	},
+	"steam_root": func(string) (string, bool) {
+		for _, dir := range steamInstallDirs() {
+			if info, err := os.Stat(dir); err == nil && info.IsDir() {
+				return dir, true
+			}
+		}
+		return "", false
+	},
+	"proton_prefix": func(appID string) (string, bool) {
+		if appID == "" {
+			return "", false
+		}
+		for _, library := range steamLibraries() {
+			prefix := filepath.Join(library, "steamapps", "compatdata", appID, "pfx")
+			if info, err := os.Stat(prefix); err == nil && info.IsDir() {
+				return prefix, true
+			}
+		}
+		return "", false
+	},
+	"exe_dir": func(string) (string, bool) {
+		exePath, err := os.Executable()
+		if err != nil {
+			return "", false
+		}
+		return filepath.Dir(exePath), true
+	},
+	"xdg_data_home": func(string) (string, bool) {
+		if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+			return dir, true
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		return filepath.Join(home, ".local", "share"), true
+	},
+}
+
+// expandPath 경로의 변수를 모두 치환. 치환할 수 없는 변수가 있으면 그 목록으로 오류
+func expandPath(path, appID string) (string, error) {
+	var unresolved []string
+	expanded := pathVarPattern.ReplaceAllStringFunc(path, func(match string) string {
+		groups := pathVarPattern.FindStringSubmatch(match)
+		var value string
+		var ok bool
+		switch {
+		case groups[1] != "":
+			value, ok = lookupEnv(groups[1])
+		case groups[2] != "":
+			value, ok = lookupEnv(groups[2])
+		case groups[3] != "":
+			value, ok = lookupEnv(groups[3])
+		default:
+			if token, known := pathTokens[groups[4]]; known {
+				value, ok = token(appID)
+			}
+		}
+		if !ok {
+			unresolved = append(unresolved, match)
+			return match
+		}
+		return value
+	})
+
+	if expanded == "~" || strings.HasPrefix(expanded, "~/") || strings.HasPrefix(expanded, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			expanded = home + expanded[1:]
+		} else {
+			unresolved = append(unresolved, "~")
+		}
+	}
+
+	if len(unresolved) > 0 {
+		return "", fmt.Errorf("치환할 수 없는 변수: %s (%s)", strings.Join(unresolved, ", "), path)
+	}
+	return expanded, nil
+}
+
+// lookupEnv 환경 변수 값 (없거나 비어 있으면 ok=false)
+func lookupEnv(name string) (string, bool) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		value, ok = os.LookupEnv(strings.ToUpper(name))
+	}
+	return value, ok && value != ""
+}
+
+// resolvePaths 프로필의 경로 항목을 치환해서 실행 중에 쓸 경로로 저장
+func (p *Profile) resolvePaths() error {
+	appID := p.steamAppID()
+
+	var errs []string
+	expand := func(field, path string) string {
+		if path == "" {
+			return ""
+		}
+		expanded, err := expandPath(path, appID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", field, err))
+			return path
+		}
+		return filepath.Clean(expanded)
+	}
+
+	p.targetFile = expand("target_file", p.TargetFile)
+	p.backupDir = expand("backup_dir", p.BackupDir)
+	p.extraTargets = nil
+	for _, extra := range p.ExtraTargets {
+		p.extraTargets = append(p.extraTargets, expand("extra_targets", extra))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
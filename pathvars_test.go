@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("SBBC_TEST_DIR", "/saves")
+	t.Setenv("SBBC_TEST_USER", "me")
+	t.Setenv("XDG_DATA_HOME", "/data")
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	exePath, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/plain/path", "/plain/path"},
+		{"%SBBC_TEST_DIR%/x", "/saves/x"},
+		{"%sbbc_test_dir%/x", "/saves/x"}, // 소문자로 써도 대문자 이름으로 찾음
+		{"$SBBC_TEST_DIR/x", "/saves/x"},
+		{"${SBBC_TEST_DIR}/x", "/saves/x"},
+		{"${SBBC_TEST_DIR}_old", "/saves_old"},
+		{"%SBBC_TEST_DIR%/$SBBC_TEST_USER/${SBBC_TEST_USER}", "/saves/me/me"},
+		{"{xdg_data_home}/Steam", "/data/Steam"},
+		{"{exe_dir}/backups", filepath.Dir(exePath) + "/backups"},
+		{"~", home},
+		{"~/saves", home + "/saves"},
+		{`~\saves`, home + `\saves`},
+		{"/a/~/b", "/a/~/b"}, // 맨 앞이 아닌 ~는 그대로
+		{"100%", "100%"},     // 변수 형태가 아니면 그대로
+		{"{NotAToken}", "{NotAToken}"},
+	}
+	for _, tt := range tests {
+		got, err := expandPath(tt.path, "")
+		if err != nil {
+			t.Errorf("expandPath(%q): %v", tt.path, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("expandPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPathUnresolved(t *testing.T) {
+	t.Setenv("SBBC_TEST_EMPTY", "")
+
+	tests := []struct {
+		path       string
+		unresolved []string
+	}{
+		{"%SBBC_TEST_MISSING%/x", []string{"%SBBC_TEST_MISSING%"}},
+		{"$SBBC_TEST_MISSING/x", []string{"$SBBC_TEST_MISSING"}},
+		{"${SBBC_TEST_MISSING}/x", []string{"${SBBC_TEST_MISSING}"}},
+		{"%SBBC_TEST_EMPTY%/x", []string{"%SBBC_TEST_EMPTY%"}}, // 빈 값도 치환하지 않음
+		{"{unknown_token}/x", []string{"{unknown_token}"}},
+		{"{proton_prefix}/drive_c", []string{"{proton_prefix}"}}, // 앱 ID가 없으면 찾을 수 없음
+		{"$SBBC_TEST_A/{unknown_token}/%SBBC_TEST_B%", []string{"$SBBC_TEST_A", "{unknown_token}", "%SBBC_TEST_B%"}},
+	}
+	for _, tt := range tests {
+		got, err := expandPath(tt.path, "")
+		if err == nil {
+			t.Errorf("expandPath(%q) = %q, expected error", tt.path, got)
+			continue
+		}
+		msg := err.Error()
+		if !strings.Contains(msg, "치환할 수 없는 변수") {
+			t.Errorf("expandPath(%q) error = %q", tt.path, msg)
+		}
+		for _, name := range tt.unresolved {
+			if !strings.Contains(msg, name) {
+				t.Errorf("expandPath(%q) error %q does not mention %s", tt.path, msg, name)
+			}
+		}
+	}
+}
+
+func TestResolvePaths(t *testing.T) {
+	t.Setenv("SBBC_TEST_DIR", "/saves")
+
+	p := &Profile{
+		Name:         "test",
+		TargetFile:   "$SBBC_TEST_DIR/save.sav",
+		BackupDir:    "%SBBC_TEST_DIR%/backups/",
+		ExtraTargets: []string{"${SBBC_TEST_DIR}/other.sav"},
+	}
+	if err := p.resolvePaths(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.targetPath(), filepath.Clean("/saves/save.sav"); got != want {
+		t.Errorf("targetPath() = %q, want %q", got, want)
+	}
+	if got, want := p.backupFolder(), filepath.Clean("/saves/backups"); got != want {
+		t.Errorf("backupFolder() = %q, want %q", got, want)
+	}
+	if len(p.extraTargets) != 1 || p.extraTargets[0] != filepath.Clean("/saves/other.sav") {
+		t.Errorf("extraTargets = %q", p.extraTargets)
+	}
+	// 설정 파일에 저장할 값은 치환 전 그대로
+	if p.TargetFile != "$SBBC_TEST_DIR/save.sav" {
+		t.Errorf("TargetFile changed to %q", p.TargetFile)
+	}
+
+	p.BackupDir = "$SBBC_TEST_MISSING/backups"
+	err := p.resolvePaths()
+	if err == nil || !strings.Contains(err.Error(), "backup_dir") {
+		t.Errorf("resolvePaths() error = %v, want backup_dir error", err)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	gameMu             sync.Mutex
+	gameSessions       = make(map[string]string) // 실행 중인 게임의 프로필 이름 → 세션 ID
+	processMonitorDone chan bool
+)
+
+// startProcessMonitor 프로필별 게임 프로세스 시작/종료를 주기적으로 확인
+func startProcessMonitor() {
+	enabled := false
+	for _, p := range allProfiles() {
+		if len(p.GameProcesses) > 0 {
+			enabled = true
+		}
+	}
+	if !enabled {
+		log.Println("게임 프로세스 이름이 설정되지 않아 세션 감지를 사용하지 않습니다")
+		return
+	}
+
+	processMonitorDone = make(chan bool)
+	done := processMonitorDone
+
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+
+		checkGameProcess()
+		for {
+			select {
+			case <-ticker.C:
+				checkGameProcess()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func stopProcessMonitor() {
+	if processMonitorDone != nil {
+		close(processMonitorDone)
+		processMonitorDone = nil
+	}
+}
+
+func checkGameProcess() {
+	names, err := listProcessNames()
+	if err != nil {
+		log.Printf("프로세스 목록 가져오기 실패: %v", err)
+		return
+	}
+
+	profiles := allProfiles()
+	configured := make(map[string]bool)
+	for _, p := range profiles {
+		if len(p.GameProcesses) == 0 {
+			continue
+		}
+		configured[p.Name] = true
+
+		running := false
+		for _, name := range names {
+			if matchGameProcess(name, p.GameProcesses) {
+				running = true
+				break
+			}
+		}
+
+		wasRunning := isProfileGameRunning(p.Name)
+
+		switch {
+		case running && !wasRunning:
+			session := beginGameSession(p.Name)
+			log.Printf("게임 실행 감지: %s (세션 %s)", p.Name, session)
+			backupProfile(p, triggerSessionStart, "세션 시작 백업")
+		case !running && wasRunning:
+			log.Printf("게임 종료 감지: %s (세션 %s)", p.Name, currentGameSession(p.Name))
+			// 종료 직후 스냅샷은 끝난 세션에 속하도록 기록한 뒤 세션을 닫음
+			backupProfile(p, triggerSessionEnd, "세션 종료 백업")
+			endGameSession(p.Name)
+		}
+		if running != wasRunning {
+			statusChanged()
+		}
+	}
+
+	// 설정에서 빠진 프로필의 세션은 닫음
+	gameMu.Lock()
+	var stale []string
+	for name := range gameSessions {
+		if !configured[name] {
+			stale = append(stale, name)
+		}
+	}
+	gameMu.Unlock()
+	for _, name := range stale {
+		endGameSession(name)
+	}
+}
+
+// beginGameSession 프로필의 새 게임 세션 시작 후 세션 ID 반환
+func beginGameSession(profile string) string {
+	gameMu.Lock()
+	defer gameMu.Unlock()
+
+	session := time.Now().Format("20060102_150405")
+	gameSessions[profile] = session
+	return session
+}
+
+func endGameSession(profile string) {
+	gameMu.Lock()
+	delete(gameSessions, profile)
+	anyRunning := len(gameSessions) > 0
+	gameMu.Unlock()
+
+	// "게임 종료까지" 일시 중지했다면 실행 중인 게임이 모두 끝났을 때 재개
+	if !anyRunning {
+		resumeAutoBackupAfterGame()
+	}
+}
+
+// isGameRunning 어느 프로필이든 게임이 실행 중인지
+func isGameRunning() bool {
+	gameMu.Lock()
+	defer gameMu.Unlock()
+	return len(gameSessions) > 0
+}
+
+func isProfileGameRunning(profile string) bool {
+	gameMu.Lock()
+	defer gameMu.Unlock()
+	_, ok := gameSessions[profile]
+	return ok
+}
+
+// currentGameSession 프로필의 진행 중인 게임 세션 ID (게임이 실행 중이 아니면 빈 문자열)
+func currentGameSession(profile string) string {
+	gameMu.Lock()
+	defer gameMu.Unlock()
+	return gameSessions[profile]
+}
+
+// matchGameProcess 프로세스 이름이 설정된 패턴 중 하나와 일치하는지 확인 (대소문자 무시)
+func matchGameProcess(name string, patterns []string) bool {
+	// Proton/Wine에서는 Windows 경로가 그대로 넘어올 수 있음
+	name = strings.ToLower(name)
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(strings.ToLower(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
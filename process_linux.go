@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// listProcessNames /proc에서 실행 중인 프로세스 이름 목록 수집
+// comm은 15자로 잘리므로 cmdline의 첫 인자(Proton에서는 Windows 경로)도 함께 반환
+func listProcessNames() ([]string, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("/proc 읽기 실패: %v", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !isNumeric(entry.Name()) {
+			continue
+		}
+		procDir := filepath.Join("/proc", entry.Name())
+
+		if comm, err := os.ReadFile(filepath.Join(procDir, "comm")); err == nil {
+			names = append(names, strings.TrimSpace(string(comm)))
+		}
+		if cmdline, err := os.ReadFile(filepath.Join(procDir, "cmdline")); err == nil && len(cmdline) > 0 {
+			if argv0, _, _ := bytes.Cut(cmdline, []byte{0}); len(argv0) > 0 {
+				names = append(names, string(argv0))
+			}
+		}
+	}
+
+	return names, nil
+}
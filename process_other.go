@@ -0,0 +1,12 @@
+//go:build !windows && !linux
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func listProcessNames() ([]string, error) {
+	return nil, fmt.Errorf("프로세스 감지를 지원하지 않는 운영체제입니다: %s", runtime.GOOS)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// 게임별 프로필
+// 설정 파일 최상위 항목(target_file, backup_dir 등)은 기본 프로필이고, profiles 목록에 다른 게임을 추가
+// 모든 프로필의 세이브를 동시에 감시하고 백업은 프로필마다 자기 폴더, 이름 규칙, 보관 개수, 검사 규칙을 따름
+
+// 설정 파일에 name이 없을 때 기본 프로필 이름
+const defaultProfileName = "Stellar Blade"
+
+// Profile 게임 하나의 백업 설정
+type Profile struct {
+	Name             string   `json:"name"`
+	TargetFile       string   `json:"target_file"`
+	ExtraTargets     []string `json:"extra_targets,omitempty"` // 같은 게임의 다른 세이브 파일
+	BackupDir        string   `json:"backup_dir"`
+	BackupPrefix     string   `json:"backup_prefix,omitempty"` // 백업 파일 이름 앞부분 (기본: 세이브 파일 이름)
+	MaxBackups       int      `json:"max_backups"`
+	MinSaveSize      int64    `json:"min_save_size"`
+	Validators       []string `json:"validators,omitempty"`
+	GameProcesses    []string `json:"game_processes"`
+	WatchAllAccounts bool     `json:"watch_all_accounts"`
+
+	// 이 프로필을 대상으로 하는 단축키 (기본 프로필은 최상위 hotkeys 사용)
+	Hotkeys map[string]string `json:"hotkeys,omitempty"`
+
+	// 경로의 {proton_prefix}에 쓸 Steam 앱 ID
+	SteamAppID string `json:"steam_app_id,omitempty"`
+
+	// 경로 변수를 치환한 실제 경로 (resolvePaths에서 채움, 설정 파일에는 치환 전 형태로 저장)
+	targetFile   string
+	backupDir    string
+	extraTargets []string
+
+	// 이 버전이 모르는 항목 (저장할 때 그대로 다시 씀)
+	extra map[string]json.RawMessage
+}
+
+// saveTarget 백업할 세이브 파일 하나와 그 백업 폴더
+type saveTarget struct {
+	Profile    *Profile
+	Account    string // Steam ID (계정 폴더가 아니면 빈 문자열)
+	TargetFile string
+	BackupDir  string
+	Prefix     string // 백업 파일 이름 앞부분
+}
+
+// profilesOf 기본 프로필과 추가 프로필 목록
+func profilesOf(cfg *Config) []*Profile {
+	profiles := []*Profile{&cfg.Profile}
+	for i := range cfg.Profiles {
+		profiles = append(profiles, &cfg.Profiles[i])
+	}
+	return profiles
+}
+
+func allProfiles() []*Profile {
+	return profilesOf(GetConfig())
+}
+
+// defaultProfile 프로필을 지정하지 않은 단축키, 퀵 세이브, 실행 래퍼가 사용하는 프로필
+func defaultProfile() *Profile {
+	return &GetConfig().Profile
+}
+
+// findProfile 이름으로 프로필 찾기 (대소문자 무시)
+func findProfile(name string) *Profile {
+	for _, p := range allProfiles() {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// steamAppID 프로필의 Steam 앱 ID. 기본 프로필은 지정하지 않아도 Stellar Blade
+func (p *Profile) steamAppID() string {
+	if p.SteamAppID == "" && p.Name == defaultProfileName {
+		return stellarBladeAppID
+	}
+	return p.SteamAppID
+}
+
+// targetPath 치환한 target_file 경로
+func (p *Profile) targetPath() string {
+	return p.targetFile
+}
+
+// backupFolder 치환한 backup_dir 경로
+func (p *Profile) backupFolder() string {
+	return p.backupDir
+}
+
+// primaryTarget 프로필의 target_file
+// watch_all_accounts가 켜져 있고 Steam ID 폴더 안의 세이브면 백업은 backup_dir/<Steam ID>에 저장
+func (p *Profile) primaryTarget() saveTarget {
+	target := p.newTarget(p.targetFile, p.backupDir)
+	target.Account = accountOf(p.targetFile)
+	if p.BackupPrefix != "" {
+		target.Prefix = p.BackupPrefix
+	}
+	if p.WatchAllAccounts && target.Account != "" {
+		target.BackupDir = filepath.Join(p.backupDir, target.Account)
+	}
+	return target
+}
+
+// targets 프로필에서 감시하고 백업할 세이브 목록 (첫 항목은 항상 primaryTarget)
+func (p *Profile) targets() []saveTarget {
+	primary := p.primaryTarget()
+	targets := []saveTarget{primary}
+	if p.WatchAllAccounts && primary.Account != "" {
+		targets = append(targets, accountTargets(p, primary)...)
+	}
+	for _, extra := range p.extraTargets {
+		targets = append(targets, p.newTarget(extra, p.backupDir))
+	}
+	return targets
+}
+
+func (p *Profile) newTarget(targetFile, backupDir string) saveTarget {
+	ext := filepath.Ext(targetFile)
+	return saveTarget{
+		Profile:    p,
+		TargetFile: targetFile,
+		BackupDir:  backupDir,
+		Prefix:     strings.TrimSuffix(filepath.Base(targetFile), ext),
+	}
+}
+
+// backupPath 백업 파일 경로: <backup_dir>/<prefix>_<suffix><세이브 확장자>
+func (t saveTarget) backupPath(suffix string) string {
+	return filepath.Join(t.BackupDir, fmt.Sprintf("%s_%s%s", t.Prefix, suffix, filepath.Ext(t.TargetFile)))
+}
+
+// isBackupName 이 세이브의 백업 파일 이름인지 (날짜, auto_, quick_ 형식만 인정해서
+// "save"와 "save_slot2"처럼 앞부분이 겹치는 세이브의 백업을 구분)
+func (t saveTarget) isBackupName(name string) bool {
+	ext := filepath.Ext(t.TargetFile)
+	if !strings.HasSuffix(name, ext) || !strings.HasPrefix(name, t.Prefix+"_") {
+		return false
+	}
+	rest := strings.TrimSuffix(strings.TrimPrefix(name, t.Prefix+"_"), ext)
+	return strings.HasPrefix(rest, "auto_") || strings.HasPrefix(rest, "quick_") ||
+		(rest != "" && rest[0] >= '0' && rest[0] <= '9')
+}
+
+// isRotatingName 개수 제한 정리에서 제외하는 순환 백업(auto_, quick_)인지
+func (t saveTarget) isRotatingName(name string) bool {
+	rest := strings.TrimPrefix(name, t.Prefix+"_")
+	return strings.HasPrefix(rest, "auto_") || strings.HasPrefix(rest, "quick_")
+}
+
+// watchTargets 모든 프로필에서 감시할 세이브 목록
+func watchTargets() []saveTarget {
+	var targets []saveTarget
+	for _, p := range allProfiles() {
+		targets = append(targets, p.targets()...)
+	}
+	return targets
+}
+
+// currentWatchTarget 감시를 시작할 때 만든 대상을 현재 설정의 같은 세이브(프로필 이름과 세이브 파일)로 다시 찾기
+// backup_dir, backup_prefix, min_save_size, validators는 감시를 다시 시작하지 않고 바뀌므로 백업할 때마다 찾음
+// 설정에서 빠진 세이브면 ok=false
+func currentWatchTarget(target saveTarget) (saveTarget, bool) {
+	for _, current := range watchTargets() {
+		if strings.EqualFold(current.Profile.Name, target.Profile.Name) &&
+			filepath.Clean(current.TargetFile) == filepath.Clean(target.TargetFile) {
+			return current, true
+		}
+	}
+	return saveTarget{}, false
+}
+
+// backupTargets 백업을 찾아볼 대상 목록
+// 계정별로 나누기 전에 backup_dir 바로 아래 만든 백업은 프로필의 primaryTarget 백업으로 취급
+func backupTargets() []saveTarget {
+	var targets []saveTarget
+	for _, p := range allProfiles() {
+		targets = append(targets, p.targets()...)
+		if primary := p.primaryTarget(); primary.BackupDir != p.backupDir {
+			primary.BackupDir = p.backupDir
+			targets = append(targets, primary)
+		}
+	}
+	return targets
+}
+
+// targetForBackup 백업 파일이 어느 세이브의 백업인지 찾기 (폴더와 이름 규칙으로)
+// 어느 프로필에도 속하지 않는 백업은 다른 세이브를 덮어쓰지 않도록 오류
+func targetForBackup(backupPath string) (saveTarget, error) {
+	dir := filepath.Clean(filepath.Dir(backupPath))
+	name := filepath.Base(backupPath)
+	for _, target := range backupTargets() {
+		if filepath.Clean(target.BackupDir) == dir && target.isBackupName(name) {
+			return target, nil
+		}
+	}
+	return saveTarget{}, fmt.Errorf("어느 프로필의 세이브 백업인지 알 수 없습니다: %s", filepath.Base(backupPath))
+}
+
+// targetMessage 알림/로그 메시지 앞에 프로필과 계정 이름 붙이기 (여러 개일 때만)
+func targetMessage(target saveTarget, message string) string {
+	var parts []string
+	if len(GetConfig().Profiles) > 0 && target.Profile != nil {
+		parts = append(parts, target.Profile.Name)
+	}
+	if target.Account != "" && target.Profile != nil && target.Profile.WatchAllAccounts {
+		parts = append(parts, accountName(target.Account))
+	}
+	if len(parts) == 0 {
+		return message
+	}
+	return fmt.Sprintf("[%s] %s", strings.Join(parts, " · "), message)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// 퀵 슬롯 수가 설정되지 않았을 때 사용할 기본값
+const defaultQuickSlots = 3
+
+var (
+	quickMu       sync.Mutex
+	quickSelected = make(map[string]int) // 프로필별 퀵 로드 대상 슬롯 (0이면 가장 최근 슬롯)
+)
+
+func quickSlotCount() int {
+	if n := GetConfig().QuickSlots; n > 0 {
+		return n
+	}
+	return defaultQuickSlots
+}
+
+// quickSlotPath 프로필 세이브의 퀵 슬롯 파일 (<prefix>_quick_N)
+func quickSlotPath(p *Profile, slot int) string {
+	return p.primaryTarget().backupPath(fmt.Sprintf("quick_%d", slot))
+}
+
+// latestQuickSlot 슬롯 count개 중 가장 최근에 저장된 퀵 슬롯 번호 (없으면 0)
+func latestQuickSlot(p *Profile, count int) int {
+	latest := 0
+	var latestTime time.Time
+	for slot := 1; slot <= count; slot++ {
+		info, err := os.Stat(quickSlotPath(p, slot))
+		if err != nil {
+			continue
+		}
+		if latest == 0 || info.ModTime().After(latestTime) {
+			latest = slot
+			latestTime = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// quickSave 퀵 슬롯 링의 다음 칸에 현재 세이브 저장
+func quickSave(p *Profile) {
+	quickMu.Lock()
+	defer quickMu.Unlock()
+
+	done := reportBusy()
+	target := p.primaryTarget()
+	count := quickSlotCount()
+	slot := latestQuickSlot(p, count)%count + 1
+	slotPath := quickSlotPath(p, slot)
+
+	err := checkSourceSave(target)
+	if err == nil {
+		err = copyFile(target.TargetFile, slotPath)
+	}
+	done(err)
+	if err != nil {
+		log.Printf("퀵 세이브 실패: %v", err)
+		if !errors.Is(err, errSaveQuarantined) {
+			notify(notifyFailure, "퀵 세이브 실패", targetMessage(target, err.Error()))
+		}
+		return
+	}
+
+	recordBackup(slotPath, target, triggerQuickSave, "")
+	quickSelected[p.Name] = slot
+	log.Printf("퀵 세이브 완료 (슬롯 %d): %s", slot, slotPath)
+	notify(notifySuccess, "퀵 세이브 완료", targetMessage(target, fmt.Sprintf("슬롯 %d", slot)))
+}
+
+// quickLoad 선택된 퀵 슬롯(기본: 가장 최근)을 복원. 복원 전 현재 세이브를 먼저 백업
+func quickLoad(p *Profile) {
+	quickMu.Lock()
+	defer quickMu.Unlock()
+
+	slot := quickSelected[p.Name]
+	if slot == 0 {
+		slot = latestQuickSlot(p, quickSlotCount())
+	}
+	if slot == 0 {
+		log.Println("퀵 로드할 슬롯이 없습니다")
+		return
+	}
+
+	if err := restoreBackup(quickSlotPath(p, slot)); err != nil {
+		log.Printf("퀵 로드 실패 (슬롯 %d): %v", slot, err)
+		return
+	}
+
+	log.Printf("퀵 로드 완료 (슬롯 %d)", slot)
+}
+
+// selectQuickSlot 저장된 퀵 슬롯 사이에서 퀵 로드 대상을 앞뒤로 이동
+func selectQuickSlot(p *Profile, delta int) {
+	quickMu.Lock()
+	defer quickMu.Unlock()
+
+	count := quickSlotCount()
+	current := quickSelected[p.Name]
+	if current == 0 {
+		current = latestQuickSlot(p, count)
+	}
+
+	for i := 1; i <= count; i++ {
+		slot := ((current-1+delta*i)%count+count)%count + 1
+		if _, err := os.Stat(quickSlotPath(p, slot)); err == nil {
+			quickSelected[p.Name] = slot
+			log.Printf("퀵 로드 슬롯 선택: %d", slot)
+			return
+		}
+	}
+
+	log.Println("선택할 수 있는 퀵 슬롯이 없습니다")
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// 세이브 경로 자리표시자 치환
+// Ludusavi 매니페스트 형식의 경로(<home>, <winLocalAppData>, <base> 등)를 환경마다 실제 경로로 바꿈
+// 환경은 이 PC 자체와, Linux에서는 Proton/Wine 접두사 안의 Windows 사용자 폴더
+
+// pathContext 자리표시자를 치환할 환경 하나
+type pathContext struct {
+	source  string            // 표시용 출처 ("Windows", "Proton (앱 3489700)", "Heroic (Game)" 등)
+	windows bool              // Windows 경로를 쓰는 환경인지 (Windows 자체 또는 Proton/Wine 접두사)
+	prefix  string            // Proton/Wine 접두사 폴더 (이 PC 자체면 빈 문자열)
+	appID   string            // Proton 접두사의 Steam 앱 ID
+	vars    map[string]string // 자리표시자 → 경로
+}
+
+var placeholderPattern = regexp.MustCompile(`<[A-Za-z]+>`)
+
+// nativeContext 이 PC 자체의 사용자 폴더
+func nativeContext() pathContext {
+	home, _ := os.UserHomeDir()
+	ctx := pathContext{vars: map[string]string{"<home>": home}}
+
+	switch runtime.GOOS {
+	case "windows":
+		ctx.source = "Windows"
+		ctx.windows = true
+		localAppData := os.Getenv("LOCALAPPDATA")
+		ctx.setVars(map[string]string{
+			"<winAppData>":         os.Getenv("APPDATA"),
+			"<winLocalAppData>":    localAppData,
+			"<winLocalAppDataLow>": filepath.Join(filepath.Dir(localAppData), "LocalLow"),
+			"<winDocuments>":       filepath.Join(home, "Documents"),
+			"<winPublic>":          os.Getenv("PUBLIC"),
+			"<winProgramData>":     os.Getenv("PROGRAMDATA"),
+			"<winDir>":             os.Getenv("WINDIR"),
+			"<osUserName>":         os.Getenv("USERNAME"),
+		})
+	default:
+		ctx.source = runtime.GOOS
+		xdgData := os.Getenv("XDG_DATA_HOME")
+		if xdgData == "" {
+			xdgData = filepath.Join(home, ".local", "share")
+		}
+		xdgConfig := os.Getenv("XDG_CONFIG_HOME")
+		if xdgConfig == "" {
+			xdgConfig = filepath.Join(home, ".config")
+		}
+		ctx.setVars(map[string]string{
+			"<xdgData>":    xdgData,
+			"<xdgConfig>":  xdgConfig,
+			"<osUserName>": os.Getenv("USER"),
+		})
+	}
+	return ctx
+}
+
+// prefixUserContext Proton/Wine 접두사 안의 Windows 사용자 폴더 (drive_c/users/<user>)
+func prefixUserContext(source, prefix, driveC, user string) pathContext {
+	home := filepath.Join(driveC, "users", user)
+	return pathContext{
+		source:  source,
+		windows: true,
+		prefix:  prefix,
+		vars: map[string]string{
+			"<home>":               home,
+			"<winAppData>":         filepath.Join(home, "AppData", "Roaming"),
+			"<winLocalAppData>":    filepath.Join(home, "AppData", "Local"),
+			"<winLocalAppDataLow>": filepath.Join(home, "AppData", "LocalLow"),
+			"<winDocuments>":       filepath.Join(home, "Documents"),
+			"<winPublic>":          filepath.Join(driveC, "users", "Public"),
+			"<winProgramData>":     filepath.Join(driveC, "ProgramData"),
+			"<winDir>":             filepath.Join(driveC, "windows"),
+			"<osUserName>":         user,
+		},
+	}
+}
+
+// driveCContexts 접두사의 drive_c 아래 사용자마다 환경 하나씩
+func driveCContexts(source, prefix, driveC string) []pathContext {
+	var contexts []pathContext
+	users, _ := filepath.Glob(filepath.Join(driveC, "users", "*"))
+	for _, dir := range users {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() || filepath.Base(dir) == "Public" {
+			continue
+		}
+		contexts = append(contexts, prefixUserContext(source, prefix, driveC, filepath.Base(dir)))
+	}
+	return contexts
+}
+
+// protonContexts Steam 라이브러리의 Proton 접두사 (appID가 비어 있으면 모든 앱)
+func protonContexts(appID string) []pathContext {
+	pattern := appID
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var contexts []pathContext
+	seen := make(map[string]bool)
+	for _, library := range steamLibraries() {
+		prefixes, _ := filepath.Glob(filepath.Join(library, "steamapps", "compatdata", pattern))
+		for _, prefix := range prefixes {
+			key := prefix
+			if resolved, err := filepath.EvalSymlinks(prefix); err == nil {
+				key = resolved
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			id := filepath.Base(prefix)
+			for _, ctx := range driveCContexts(fmt.Sprintf("Proton (앱 %s)", id), prefix, filepath.Join(prefix, "pfx", "drive_c")) {
+				ctx.appID = id
+				contexts = append(contexts, ctx)
+			}
+		}
+	}
+	return contexts
+}
+
+// wineContexts Heroic, Lutris, 직접 만든 Wine 접두사
+func wineContexts() []pathContext {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	var contexts []pathContext
+	wine := []struct{ pattern, source string }{
+		{filepath.Join(home, "Games", "Heroic", "Prefixes", "*", "*"), "Heroic"},
+		{filepath.Join(home, "Games", "*"), "Wine 접두사"},
+		{filepath.Join(home, ".wine"), "Wine"},
+	}
+	for _, w := range wine {
+		prefixes, _ := filepath.Glob(w.pattern)
+		for _, prefix := range prefixes {
+			source := fmt.Sprintf("%s (%s)", w.source, filepath.Base(prefix))
+			contexts = append(contexts, driveCContexts(source, prefix, filepath.Join(prefix, "drive_c"))...)
+		}
+	}
+	return contexts
+}
+
+// windowsContexts Windows 경로를 찾아볼 환경 목록
+// Windows면 이 PC 자체, Linux면 모든 Proton/Wine 접두사
+func windowsContexts() []pathContext {
+	switch runtime.GOOS {
+	case "windows":
+		return []pathContext{nativeContext()}
+	case "linux":
+		return append(protonContexts(""), wineContexts()...)
+	default:
+		return nil
+	}
+}
+
+// setVars 자리표시자 값 추가 (빈 값은 치환할 수 없는 것으로 취급)
+func (c *pathContext) setVars(vars map[string]string) {
+	for name, value := range vars {
+		if value != "" {
+			c.vars[name] = value
+		}
+	}
+}
+
+// with 자리표시자 값을 더한 복사본 (게임별 <base>, <root> 등)
+func (c pathContext) with(vars map[string]string) pathContext {
+	copied := c
+	copied.vars = make(map[string]string, len(c.vars)+len(vars))
+	for name, value := range c.vars {
+		copied.vars[name] = value
+	}
+	copied.setVars(vars)
+	return copied
+}
+
+// resolve 경로의 자리표시자를 치환. 이 환경에서 알 수 없는 자리표시자가 있으면 ok=false
+// <storeUserId>는 계정마다 폴더가 다르므로 *로 바꿔 glob으로 찾음
+func (c pathContext) resolve(pattern string) (path string, ok bool) {
+	ok = true
+	path = placeholderPattern.ReplaceAllStringFunc(pattern, func(name string) string {
+		if name == "<storeUserId>" {
+			return "*"
+		}
+		value, found := c.vars[name]
+		if !found {
+			ok = false
+		}
+		return value
+	})
+	if !ok {
+		return "", false
+	}
+	return filepath.Clean(filepath.FromSlash(strings.ReplaceAll(path, `\`, "/"))), true
+}
+
+// glob 자리표시자를 치환하고 일치하는 파일/폴더 목록
+func (c pathContext) glob(pattern string) []string {
+	path, ok := c.resolve(pattern)
+	if !ok {
+		return nil
+	}
+	// Ludusavi의 **는 filepath.Glob에서 *와 같게 취급 (찾은 폴더는 호출하는 쪽에서 하위까지 탐색)
+	matches, _ := filepath.Glob(strings.ReplaceAll(path, "**", "*"))
+	return matches
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+)
+
+// settings.json의 JSON Schema
+// Config 구조체에서 만들어 설정 파일 옆에 settings.schema.json으로 씀
+// 설정 파일의 "$schema"가 이 파일을 가리키므로 VS Code 등 편집기에서 자동 완성과 검사가 됨
+
+// schemaDescriptions 항목 이름별 설명 (최상위와 profiles 안에서 같은 이름이면 같은 설명)
+var schemaDescriptions = map[string]string{
+	"$schema":                  "편집기에서 쓸 JSON Schema 파일",
+	"version":                  "설정 파일 버전 (프로그램이 관리, 직접 바꾸지 마세요)",
+	"name":                     "프로필 이름",
+	"target_file":              "백업할 세이브 파일 경로 (%LOCALAPPDATA%, ~, {steam_id} 등 경로 변수 사용 가능)",
+	"extra_targets":            "함께 백업할 같은 게임의 다른 세이브 파일 목록",
+	"backup_dir":               "백업 파일을 저장할 폴더 (경로 변수 사용 가능)",
+	"backup_prefix":            "백업 파일 이름 앞부분 (기본: 세이브 파일 이름)",
+	"max_backups":              "세이브마다 보관할 누적 백업 개수 (0은 무제한)",
+	"min_save_size":            "이 크기(바이트)보다 작은 세이브는 손상 의심으로 격리 (0이면 빈 파일만)",
+	"validators":               "백업 전 세이브 형식 검사",
+	"game_processes":           "게임 프로세스 이름 패턴 (*, ? 사용 가능, 빈 목록이면 세션 감지 안 함)",
+	"watch_all_accounts":       "모든 Steam 계정의 세이브를 감시",
+	"hotkeys":                  "단축키 조합 → 동작 (예: \"ctrl+alt+f10\": \"backup_labeled\")",
+	"steam_app_id":             "경로의 {proton_prefix}에 쓸 Steam 앱 ID",
+	"hotkey_combo":             "수동 백업 단축키 (ctrl+shift+b 형식)",
+	"auto_backup":              "세이브 파일이 바뀔 때마다 자동 백업",
+	"auto_backup_in_game_only": "게임이 실행 중일 때만 자동 백업",
+	"quick_save_hotkey":        "퀵 세이브 단축키 (빈 값이면 사용 안 함)",
+	"quick_load_hotkey":        "퀵 로드 단축키 (빈 값이면 사용 안 함)",
+	"quick_next_hotkey":        "다음 퀵 슬롯 선택 단축키 (빈 값이면 사용 안 함)",
+	"quick_prev_hotkey":        "이전 퀵 슬롯 선택 단축키 (빈 값이면 사용 안 함)",
+	"quick_slots":              "퀵 슬롯 링 크기 (0이면 기본 3)",
+	"chord_timeout_ms":         "연속 입력 단축키의 후속 키 대기 시간 (밀리초, 0이면 기본 2000)",
+	"hotkey_fallbacks":         "단축키 등록 실패 시 순서대로 시도할 대체 조합",
+	"recent_backups":           "트레이 최근 백업 메뉴에 표시할 개수 (0이면 기본 10)",
+	"notifications":            "알림 종류별 사용 여부",
+	"success":                  "백업 성공 알림",
+	"failure":                  "백업/복원/단축키 등록 실패 알림",
+	"warning":                  "단축키를 대체 조합으로 등록하는 등 경고 알림",
+	"quarantine":               "손상 의심 세이브 격리 알림",
+	"rollback":                 "세이브가 이전 시점으로 되돌아갔을 때 알림",
+	"restore":                  "복원 완료 알림",
+	"min_interval_seconds":     "같은 종류 알림 사이 최소 간격 (초)",
+	"profiles":                 "다른 게임 프로필 목록",
+}
+
+// configSchema settings.json의 JSON Schema
+func configSchema() map[string]any {
+	schema := objectSchema(reflect.TypeOf(Config{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "SB Backup Creator settings.json"
+
+	properties := schema["properties"].(map[string]any)
+	properties["version"].(map[string]any)["const"] = configVersion
+	profile := properties["profiles"].(map[string]any)["items"].(map[string]any)
+	profile["required"] = []string{"name", "target_file", "backup_dir"}
+	return schema
+}
+
+// propertySchema 항목 하나의 스키마 (설명, 검사 이름과 동작 이름 목록 추가)
+func propertySchema(name string, t reflect.Type) map[string]any {
+	property := typeSchema(t)
+	if description, ok := schemaDescriptions[name]; ok {
+		property["description"] = description
+	}
+
+	switch name {
+	case "validators":
+		property["items"] = map[string]any{"type": "string", "enum": sortedFuncKeys(saveValidators)}
+	case "hotkeys":
+		// 동작 뒤에 :인자가 붙을 수 있어 enum 대신 예시로 자동 완성
+		property["additionalProperties"] = map[string]any{"type": "string", "examples": sortedFuncKeys(hotkeyActions)}
+	}
+	return property
+}
+
+func sortedFuncKeys[F any](m map[string]F) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// objectSchema 구조체의 JSON 항목 (포함한 구조체의 항목 포함). 모르는 항목도 허용 (저장할 때 유지하므로)
+func objectSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				collect(field.Type)
+				continue
+			}
+			name := jsonFieldName(field)
+			if !field.IsExported() || name == "" {
+				continue
+			}
+			properties[name] = propertySchema(name, field.Type)
+		}
+	}
+	collect(t)
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": true,
+	}
+}
+
+// typeSchema Go 형식에 맞는 스키마
+func typeSchema(t reflect.Type) map[string]any {
+	switch t {
+	case reflect.TypeOf(profileList{}):
+		return map[string]any{"type": []string{"array", "null"}, "items": objectSchema(reflect.TypeOf(Profile{}))}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		// 설정의 숫자는 모두 개수, 크기, 시간이라 음수는 허용하지 않음
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Slice:
+		// 비어 있는 목록과 맵은 null로 저장될 수 있음
+		return map[string]any{"type": []string{"array", "null"}, "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": []string{"object", "null"}, "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return objectSchema(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+// encodeConfigSchema JSON Schema 파일 내용
+func encodeConfigSchema() ([]byte, error) {
+	data, err := json.MarshalIndent(configSchema(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("JSON Schema 생성 실패: %v", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// writeConfigSchema 설정 파일 옆의 settings.schema.json을 현재 버전에 맞게 갱신 (내용이 같으면 그대로 둠)
+func writeConfigSchema() {
+	data, err := encodeConfigSchema()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	path := filepath.Join(filepath.Dir(configPath), configSchemaName)
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Printf("JSON Schema 저장 실패: %v", err)
+	}
+}
+
+// runConfigSchema config schema 명령: JSON Schema를 파일로 쓰거나 (파일을 지정하지 않으면) 출력
+func runConfigSchema(path string) int {
+	data, err := encodeConfigSchema()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if path == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "JSON Schema 저장 실패: %v\n", err)
+		return 1
+	}
+	fmt.Printf("JSON Schema를 저장했습니다: %s\n", path)
+	return 0
+}
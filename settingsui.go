@@ -0,0 +1,236 @@
+package main
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 설정 창
+// 트레이 인스턴스가 127.0.0.1의 임의 포트에서 설정 페이지와 처음 실행 마법사를 제공하고 기본 브라우저로 열기
+// 저장하면 검사 후 saveConfig로 기록하고 파일 감시/단축키/프로세스 감지에 바로 적용
+
+//go:embed web/settings.html
+var settingsPage []byte
+
+//go:embed web/wizard.html
+var wizardPage []byte
+
+var (
+	settingsMu    sync.Mutex
+	settingsAddr  string
+	settingsToken string
+)
+
+// showSettingsDialog 설정 창 열기
+func showSettingsDialog() {
+	openSettingsPage("/")
+}
+
+// openSettingsPage 설정 서버의 페이지를 브라우저로 열기 (처음 호출할 때 설정 서버 시작)
+func openSettingsPage(page string) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
+	if settingsAddr == "" {
+		if err := startSettingsServer(); err != nil {
+			log.Printf("설정 창 시작 실패: %v", err)
+			notify(notifyFailure, "설정 창 시작 실패", err.Error())
+			return
+		}
+	}
+	openURL(fmt.Sprintf("http://%s%s?token=%s", settingsAddr, page, settingsToken))
+}
+
+func startSettingsServer() error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("설정 서버 시작 실패: %v", err)
+	}
+
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		listener.Close()
+		return fmt.Errorf("설정 서버 토큰 생성 실패: %v", err)
+	}
+	settingsToken = hex.EncodeToString(tokenBytes)
+	settingsAddr = listener.Addr().String()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(settingsPage)
+	})
+	mux.HandleFunc("/wizard", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(wizardPage)
+	})
+	mux.HandleFunc("/api/config", handleSettingsAPI)
+	mux.HandleFunc("/api/wizard", handleWizardAPI)
+
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			log.Printf("설정 서버 종료: %v", err)
+		}
+	}()
+	return nil
+}
+
+// handleSettingsAPI GET: 현재 설정과 동작 목록, POST: 검사 후 저장 및 적용
+func handleSettingsAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Token") != settingsToken {
+		writeSettingsResponse(w, http.StatusForbidden, map[string]string{"error": "잘못된 토큰입니다"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		actions := make([]string, 0, len(hotkeyActions))
+		for name := range hotkeyActions {
+			actions = append(actions, name)
+		}
+		sort.Strings(actions)
+		validators := make([]string, 0, len(saveValidators))
+		for name := range saveValidators {
+			validators = append(validators, name)
+		}
+		sort.Strings(validators)
+		// 모르는 항목도 보내서 저장할 때 그대로 돌아오게 함
+		data, err := encodeConfig(GetConfig())
+		if err != nil {
+			writeSettingsResponse(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeSettingsResponse(w, http.StatusOK, map[string]any{"config": json.RawMessage(data), "actions": actions, "validators": validators})
+
+	case http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeSettingsResponse(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("설정을 읽을 수 없습니다: %v", err)})
+			return
+		}
+		cfg, err := decodeConfig(body)
+		if err != nil {
+			writeSettingsResponse(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("설정을 읽을 수 없습니다: %v", err)})
+			return
+		}
+		if errs := validateSettings(cfg); len(errs) > 0 {
+			messages := make([]string, len(errs))
+			for i, err := range errs {
+				messages[i] = err.Error()
+			}
+			writeSettingsResponse(w, http.StatusBadRequest, map[string][]string{"errors": messages})
+			return
+		}
+		if err := applySettings(cfg); err != nil {
+			writeSettingsResponse(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeSettingsResponse(w, http.StatusOK, map[string]bool{"ok": true})
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeSettingsResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// applySettings 설정을 저장하고 바뀐 부분을 실행 중인 기능에 반영 (게시하면 applyConfigChanges가 호출됨)
+func applySettings(cfg *Config) error {
+	if cfg.Name == "" {
+		cfg.Name = defaultProfileName
+	}
+
+	if err := saveConfig(cfg); err != nil {
+		return err
+	}
+	log.Println("설정 저장 완료")
+	return nil
+}
+
+// reloadConfig 다른 프로세스(명령줄 하위 명령)가 바꾼 설정 파일을 다시 읽어 적용
+func reloadConfig() error {
+	if err := loadConfig(); err != nil {
+		return err
+	}
+	log.Println("설정 다시 읽기 완료")
+	return nil
+}
+
+// applyConfigChanges 바뀐 설정을 실행 중인 기능(파일 감시, 프로세스 감지, 단축키, 트레이 메뉴)에 반영
+// 트레이 실행 중에만 onConfigChange로 등록
+func applyConfigChanges(cfg, previous *Config) {
+	if watchChanged(cfg, previous) {
+		go restartFileWatcher()
+	}
+	if processesChanged(cfg, previous) {
+		stopProcessMonitor()
+		startProcessMonitor()
+	}
+	if retentionChanged(cfg, previous) {
+		go cleanupAllBackups()
+	}
+	updateHotkeys()
+	refreshHotkeyMenu()
+	refreshRecentBackupsMenu()
+	refreshProfilesMenu()
+	statusChanged()
+}
+
+// watchChanged 감시할 세이브 목록이 바뀌었는지
+// 백업 폴더, 이름, 검사 규칙은 자동 백업할 때 현재 설정에서 다시 찾으므로 (currentWatchTarget) 비교하지 않음
+func watchChanged(cfg, previous *Config) bool {
+	var before, after []string
+	for _, p := range profilesOf(previous) {
+		before = append(before, p.Name, p.targetPath(), fmt.Sprint(p.WatchAllAccounts), strings.Join(p.extraTargets, "\n"))
+	}
+	for _, p := range profilesOf(cfg) {
+		after = append(after, p.Name, p.targetPath(), fmt.Sprint(p.WatchAllAccounts), strings.Join(p.extraTargets, "\n"))
+	}
+	return !reflect.DeepEqual(before, after)
+}
+
+// retentionChanged 프로필의 max_backups가 줄었는지 (늘어난 경우는 정리할 것이 없음)
+func retentionChanged(cfg, previous *Config) bool {
+	before := make(map[string]int)
+	for _, p := range profilesOf(previous) {
+		before[p.Name] = p.MaxBackups
+	}
+	for _, p := range profilesOf(cfg) {
+		if p.MaxBackups > 0 && (before[p.Name] <= 0 || p.MaxBackups < before[p.Name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// processesChanged 프로필별 게임 프로세스 설정이 바뀌었는지
+func processesChanged(cfg, previous *Config) bool {
+	before := make(map[string][]string)
+	for _, p := range profilesOf(previous) {
+		before[p.Name] = p.GameProcesses
+	}
+	after := make(map[string][]string)
+	for _, p := range profilesOf(cfg) {
+		after[p.Name] = p.GameProcesses
+	}
+	return !reflect.DeepEqual(before, after)
+}
@@ -0,0 +1,53 @@
+//go:build !windows
+
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/sqweek/dialog"
+	"golang.org/x/sys/unix"
+)
+
+// 상태 폴더의 잠금 파일. 프로세스가 끝나면 운영체제가 잠금을 풀어 줌
+var lockFile *os.File
+
+// ensureSingleInstance 단일 인스턴스 보장 (잠금 파일에 flock)
+func ensureSingleInstance() bool {
+	path, err := stateFilePath("instance.lock")
+	if err != nil {
+		log.Printf("잠금 파일 경로 가져오기 실패: %v", err)
+		return false
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		log.Printf("잠금 파일 열기 실패: %v", err)
+		return false
+	}
+
+	if err := unix.Flock(int(file.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		file.Close()
+		log.Println("이미 실행 중인 인스턴스가 있습니다.")
+		showAlreadyRunningMessage()
+		return false
+	}
+
+	lockFile = file
+	log.Println("단일 인스턴스 확인 완료")
+	return true
+}
+
+// releaseSingleInstance 단일 인스턴스 해제
+func releaseSingleInstance() {
+	if lockFile != nil {
+		unix.Flock(int(lockFile.Fd()), unix.LOCK_UN)
+		lockFile.Close()
+		lockFile = nil
+	}
+}
+
+// showAlreadyRunningMessage 이미 실행 중임을 알리는 메시지
+func showAlreadyRunningMessage() {
+	dialog.Message("이미 실행 중인 SB Backup Creator가 있습니다.\n시스템 트레이를 확인해주세요.").Title("SB Backup Creator").Info()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// 트레이 아이콘으로 표시하는 상태
+type trayState int
+
+const (
+	stateIdle    trayState = iota // 대기 중
+	stateBusy                     // 백업/복원 중
+	statePaused                   // 자동 백업 꺼짐 또는 일시 중지
+	stateError                    // 마지막 작업 실패
+	stateMissing                  // 감시 대상 폴더 없음
+)
+
+var (
+	statusMu        sync.Mutex
+	busyCount       int
+	lastSuccess     time.Time
+	lastError       string
+	lastErrorTime   time.Time
+	targetMissing   bool
+	statusListeners []func()
+)
+
+// onStatusChange 상태가 바뀔 때마다 호출할 함수 등록
+func onStatusChange(listener func()) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	statusListeners = append(statusListeners, listener)
+}
+
+func changeStatus(change func()) {
+	statusMu.Lock()
+	change()
+	listeners := statusListeners
+	statusMu.Unlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+// reportBusy 백업/복원 작업 시작. 반환된 함수로 결과를 보고
+func reportBusy() func(err error) {
+	changeStatus(func() { busyCount++ })
+
+	return func(err error) {
+		changeStatus(func() {
+			busyCount--
+			if err != nil {
+				lastError = err.Error()
+				lastErrorTime = time.Now()
+			} else {
+				lastSuccess = time.Now()
+			}
+		})
+	}
+}
+
+// reportError 작업 외부에서 생긴 오류 (감시 실패 등)
+func reportError(err error) {
+	changeStatus(func() {
+		lastError = err.Error()
+		lastErrorTime = time.Now()
+	})
+}
+
+// statusChanged 자동 백업 설정처럼 상태 변수 밖의 값이 바뀌었을 때 표시 갱신
+func statusChanged() {
+	changeStatus(func() {})
+}
+
+func setTargetMissing(missing bool) {
+	changeStatus(func() { targetMissing = missing })
+}
+
+// currentTrayState 우선순위: 작업 중 > 오류 > 대상 없음 > 일시 중지 > 대기
+func currentTrayState() trayState {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+
+	switch {
+	case busyCount > 0:
+		return stateBusy
+	case lastError != "" && lastErrorTime.After(lastSuccess):
+		return stateError
+	case targetMissing:
+		return stateMissing
+	case !GetConfig().AutoBackup || isAutoBackupPaused():
+		return statePaused
+	default:
+		return stateIdle
+	}
+}
+
+// statusSnapshot 트레이 표시용 마지막 성공 시각과 최근 오류
+func statusSnapshot() (success time.Time, errMessage string, errTime time.Time) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	return lastSuccess, lastError, lastErrorTime
+}
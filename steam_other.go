@@ -0,0 +1,7 @@
+//go:build !windows && !linux
+
+package main
+
+func steamInstallDirs() []string {
+	return nil
+}
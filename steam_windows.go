@@ -0,0 +1,20 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// steamInstallDirs Steam 설치 폴더 후보 (레지스트리의 SteamPath와 기본 설치 위치)
+func steamInstallDirs() []string {
+	var dirs []string
+	if key, err := registry.OpenKey(registry.CURRENT_USER, `Software\Valve\Steam`, registry.QUERY_VALUE); err == nil {
+		if path, _, err := key.GetStringValue("SteamPath"); err == nil && path != "" {
+			dirs = append(dirs, filepath.Clean(path))
+		}
+		key.Close()
+	}
+	return append(dirs, filepath.Join(os.Getenv("ProgramFiles(x86)"), "Steam"))
+}
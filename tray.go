@@ -0,0 +1,475 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/getlantern/systray"
+	"github.com/sqweek/dialog"
+)
+
+const trayTooltip = "Stellar Blade Save Backup Tool"
+
+var (
+	trayMu             sync.Mutex
+	trayHotkeyMenu     *systray.MenuItem
+	trayHotkeyItems    []*systray.MenuItem
+	trayHotkeyFailures int
+	trayStatusItem     *systray.MenuItem
+	trayErrorItem      *systray.MenuItem
+	trayCurrentState   trayState = -1
+)
+
+// setupStatusMenu 메뉴 맨 위의 상태 표시줄(마지막 백업, 최근 오류) 생성
+// 감시/백업 함수가 상태를 보고할 때마다 아이콘, 툴팁, 상태 줄을 갱신
+func setupStatusMenu() {
+	trayMu.Lock()
+	trayStatusItem = systray.AddMenuItem("", "")
+	trayStatusItem.Disable()
+	trayErrorItem = systray.AddMenuItem("", "")
+	trayErrorItem.Disable()
+	trayErrorItem.Hide()
+	trayMu.Unlock()
+
+	onStatusChange(refreshTrayStatus)
+	onCatalogChange(refreshTrayStatus)
+	refreshTrayStatus()
+}
+
+// refreshTrayStatus 현재 상태에 맞게 아이콘, 툴팁, 상태 줄 갱신
+func refreshTrayStatus() {
+	state := currentTrayState()
+	success, errMessage, errTime := statusSnapshot()
+	backups, _ := listBackups()
+
+	trayMu.Lock()
+	defer trayMu.Unlock()
+
+	if trayStatusItem == nil {
+		return
+	}
+
+	if state != trayCurrentState {
+		systray.SetIcon(stateIcon(state))
+		trayCurrentState = state
+	}
+
+	// 이번 실행에서 백업하지 않았으면 가장 최근 백업 파일 시각 표시
+	if success.IsZero() && len(backups) > 0 {
+		success = backups[0].Created
+	}
+	statusLine := fmt.Sprintf("%s · 백업 %d개", trayStateNames[state], len(backups))
+	if !success.IsZero() {
+		statusLine = fmt.Sprintf("마지막 백업 %s · 백업 %d개", success.Format("01-02 15:04:05"), len(backups))
+	}
+	trayStatusItem.SetTitle(statusLine)
+
+	tooltip := trayTooltip + "\n" + statusLine
+	if state != stateIdle {
+		tooltip += "\n" + trayStateNames[state]
+	}
+	if targets := watchTargets(); len(targets) > 1 {
+		tooltip += fmt.Sprintf("\n세이브 %d개 감시 중", len(targets))
+	}
+	if trayHotkeyFailures > 0 {
+		tooltip += fmt.Sprintf("\n단축키 %d개 등록 실패", trayHotkeyFailures)
+	}
+	systray.SetTooltip(tooltip)
+
+	if errMessage == "" {
+		trayErrorItem.Hide()
+	} else {
+		trayErrorItem.SetTitle(fmt.Sprintf("오류 (%s): %s", errTime.Format("15:04:05"), errMessage))
+		trayErrorItem.Show()
+	}
+}
+
+// 상태 표시 이름
+var trayStateNames = map[trayState]string{
+	stateIdle:    "대기 중",
+	stateBusy:    "백업 중",
+	statePaused:  "자동 백업 중지됨",
+	stateError:   "마지막 작업 실패",
+	stateMissing: "세이브 폴더를 찾을 수 없음",
+}
+
+// setupHotkeyMenu 단축키 등록 상태를 보여주는 하위 메뉴 생성
+func setupHotkeyMenu() {
+	trayMu.Lock()
+	trayHotkeyMenu = systray.AddMenuItem("단축키", "등록된 단축키 목록")
+	trayMu.Unlock()
+
+	refreshHotkeyMenu()
+}
+
+// refreshHotkeyMenu 단축키 상태가 바뀔 때 메뉴와 툴팁 갱신
+// systray는 메뉴 항목을 지울 수 없으므로 항목을 재사용하고 남는 것은 숨김
+func refreshHotkeyMenu() {
+	statuses := hotkeyStatusList()
+
+	trayMu.Lock()
+	// 트레이 없이 실행 중 (명령줄 모드)
+	if trayHotkeyMenu == nil {
+		trayMu.Unlock()
+		return
+	}
+
+	failed := 0
+	for i, status := range statuses {
+		if i >= len(trayHotkeyItems) {
+			item := trayHotkeyMenu.AddSubMenuItem("", "")
+			item.Disable()
+			trayHotkeyItems = append(trayHotkeyItems, item)
+		}
+		trayHotkeyItems[i].SetTitle(status.String())
+		trayHotkeyItems[i].Show()
+		if status.Error != "" {
+			failed++
+		}
+	}
+	for _, item := range trayHotkeyItems[len(statuses):] {
+		item.Hide()
+	}
+
+	title := fmt.Sprintf("단축키 (%d)", len(statuses))
+	if failed > 0 {
+		title = fmt.Sprintf("단축키 (%d, 실패 %d)", len(statuses), failed)
+	}
+	trayHotkeyMenu.SetTitle(title)
+	trayHotkeyFailures = failed
+	trayMu.Unlock()
+
+	refreshTrayStatus()
+}
+
+// 최근 백업 메뉴 항목 수 기본값
+const defaultRecentBackups = 10
+
+// recentBackupSlot "최근 백업" 하위 메뉴의 항목 하나와 하위 동작들
+type recentBackupSlot struct {
+	item    *systray.MenuItem
+	restore *systray.MenuItem
+	open    *systray.MenuItem
+	pin     *systray.MenuItem
+	delete  *systray.MenuItem
+	backup  backupInfo // 현재 표시 중인 백업 (trayMu로 보호)
+}
+
+var (
+	trayRecentMenu  *systray.MenuItem
+	trayRecentSlots []*recentBackupSlot
+)
+
+// 백업 원인 표시 이름
+var triggerNames = map[string]string{
+	triggerAuto:         "자동",
+	triggerManual:       "수동",
+	triggerHotkey:       "단축키",
+	triggerSessionStart: "세션 시작",
+	triggerSessionEnd:   "세션 종료",
+	triggerQuickSave:    "퀵 세이브",
+	triggerPreRestore:   "복원 전",
+	triggerBaseline:     "기준",
+	triggerImport:       "가져옴",
+}
+
+func recentBackupCount() int {
+	if n := GetConfig().RecentBackups; n > 0 {
+		return n
+	}
+	return defaultRecentBackups
+}
+
+// setupRecentBackupsMenu 최근 백업 목록 하위 메뉴 생성. 카탈로그가 바뀔 때마다 갱신
+func setupRecentBackupsMenu() {
+	trayMu.Lock()
+	trayRecentMenu = systray.AddMenuItem("최근 백업", "최근 백업 복원/관리")
+	trayMu.Unlock()
+
+	onCatalogChange(refreshRecentBackupsMenu)
+	refreshRecentBackupsMenu()
+}
+
+// refreshRecentBackupsMenu 최근 백업 목록 다시 읽어서 메뉴 갱신
+func refreshRecentBackupsMenu() {
+	backups, err := listBackups()
+	if err != nil {
+		backups = nil
+	}
+
+	trayMu.Lock()
+	defer trayMu.Unlock()
+
+	if trayRecentMenu == nil {
+		return
+	}
+
+	// 설정에서 개수를 늘리면 메뉴 항목을 추가하고, 줄이면 남는 항목은 숨김
+	count := recentBackupCount()
+	for len(trayRecentSlots) < count {
+		addRecentBackupSlot()
+	}
+
+	for i, slot := range trayRecentSlots {
+		if i >= len(backups) || i >= count {
+			slot.item.Hide()
+			continue
+		}
+
+		backup := backups[i]
+		slot.backup = backup
+		slot.item.SetTitle(recentBackupTitle(backup))
+		slot.item.SetTooltip(backup.Path)
+		if backup.Pinned {
+			slot.pin.SetTitle("고정 해제")
+		} else {
+			slot.pin.SetTitle("고정")
+		}
+		slot.item.Show()
+	}
+
+	if len(backups) == 0 {
+		trayRecentMenu.Disable()
+	} else {
+		trayRecentMenu.Enable()
+	}
+}
+
+// addRecentBackupSlot 최근 백업 하위 메뉴에 항목 하나 추가 (trayMu를 잡은 상태에서 호출)
+func addRecentBackupSlot() {
+	slot := &recentBackupSlot{item: trayRecentMenu.AddSubMenuItem("", "")}
+	slot.restore = slot.item.AddSubMenuItem("복원", "이 백업으로 세이브 파일 복원")
+	slot.open = slot.item.AddSubMenuItem("위치 열기", "탐색기에서 백업 파일 위치 열기")
+	slot.pin = slot.item.AddSubMenuItem("고정", "오래된 백업 정리에서 제외")
+	slot.delete = slot.item.AddSubMenuItem("삭제", "이 백업 파일 삭제")
+	trayRecentSlots = append(trayRecentSlots, slot)
+	go slot.handleClicks()
+}
+
+// recentBackupTitle "06-19 14:30:22 · 수동 · 라벨" 형식
+func recentBackupTitle(backup backupInfo) string {
+	title := backup.Created.Format("01-02 15:04:05")
+	if name, ok := triggerNames[backup.Trigger]; ok {
+		title += " · " + name
+	}
+	if backup.Label != "" {
+		title += " · " + backup.Label
+	}
+	if len(GetConfig().Profiles) > 0 && backup.Profile != "" {
+		title = backup.Profile + " · " + title
+	}
+	if backup.Account != "" {
+		title += " · " + accountName(backup.Account)
+	}
+	if backup.Pinned {
+		title = "[고정] " + title
+	}
+	return title
+}
+
+func (slot *recentBackupSlot) current() backupInfo {
+	trayMu.Lock()
+	defer trayMu.Unlock()
+	return slot.backup
+}
+
+func (slot *recentBackupSlot) handleClicks() {
+	for {
+		select {
+		case <-slot.restore.ClickedCh:
+			backup := slot.current()
+			if !dialog.Message("이 백업으로 복원하시겠습니까?\n%s\n\n현재 세이브는 복원 전에 자동으로 백업됩니다.", recentBackupTitle(backup)).Title("백업 복원").YesNo() {
+				continue
+			}
+			if err := restoreBackup(backup.Path); err != nil {
+				log.Printf("백업 복원 실패: %v", err)
+				dialog.Message("복원에 실패했습니다: %v", err).Error()
+				continue
+			}
+			log.Printf("백업 복원 완료: %s", backup.Path)
+		case <-slot.open.ClickedCh:
+			openBackupLocation(slot.current().Path)
+		case <-slot.pin.ClickedCh:
+			backup := slot.current()
+			setBackupPinned(backup.Path, !backup.Pinned)
+		case <-slot.delete.ClickedCh:
+			backup := slot.current()
+			if !dialog.Message("이 백업을 삭제하시겠습니까?\n%s", recentBackupTitle(backup)).Title("백업 삭제").YesNo() {
+				continue
+			}
+			if err := deleteBackup(backup.Path); err != nil {
+				log.Printf("%v", err)
+				dialog.Message("삭제에 실패했습니다: %v", err).Error()
+			}
+		}
+	}
+}
+
+// profileMenuSlot "게임 프로필" 하위 메뉴의 프로필 하나와 하위 동작들
+type profileMenuSlot struct {
+	item    *systray.MenuItem
+	backup  *systray.MenuItem
+	restore *systray.MenuItem
+	open    *systray.MenuItem
+	profile string // 현재 표시 중인 프로필 이름 (trayMu로 보호)
+}
+
+var (
+	trayProfilesMenu *systray.MenuItem
+	trayProfileSlots []*profileMenuSlot
+)
+
+// setupProfilesMenu 프로필별 하위 메뉴 생성. 설정, 상태, 카탈로그가 바뀔 때마다 갱신
+func setupProfilesMenu() {
+	trayMu.Lock()
+	trayProfilesMenu = systray.AddMenuItem("게임 프로필", "프로필별 백업")
+	trayMu.Unlock()
+
+	onStatusChange(refreshProfilesMenu)
+	onCatalogChange(refreshProfilesMenu)
+	refreshProfilesMenu()
+}
+
+// refreshProfilesMenu 프로필 목록과 프로필별 마지막 백업, 게임 실행 여부 표시
+func refreshProfilesMenu() {
+	profiles := allProfiles()
+	backups, _ := listBackups()
+	lastBackup := make(map[string]time.Time)
+	for _, backup := range backups {
+		if backup.Created.After(lastBackup[backup.Profile]) {
+			lastBackup[backup.Profile] = backup.Created
+		}
+	}
+
+	trayMu.Lock()
+	defer trayMu.Unlock()
+
+	if trayProfilesMenu == nil {
+		return
+	}
+
+	for i, p := range profiles {
+		if i >= len(trayProfileSlots) {
+			slot := &profileMenuSlot{item: trayProfilesMenu.AddSubMenuItem("", "")}
+			slot.backup = slot.item.AddSubMenuItem("지금 백업", "이 프로필의 세이브 백업")
+			slot.restore = slot.item.AddSubMenuItem("최근 백업 복원", "이 프로필의 가장 최근 백업으로 복원")
+			slot.open = slot.item.AddSubMenuItem("백업 폴더 열기", "이 프로필의 백업 폴더 열기")
+			trayProfileSlots = append(trayProfileSlots, slot)
+			go slot.handleClicks()
+		}
+
+		slot := trayProfileSlots[i]
+		slot.profile = p.Name
+		title := p.Name
+		if isProfileGameRunning(p.Name) {
+			title += " · 실행 중"
+		}
+		if last := lastBackup[p.Name]; !last.IsZero() {
+			title += " · 마지막 백업 " + last.Format("01-02 15:04")
+		}
+		slot.item.SetTitle(title)
+		slot.item.SetTooltip(p.targetPath())
+		slot.item.Show()
+	}
+	for _, slot := range trayProfileSlots[len(profiles):] {
+		slot.item.Hide()
+	}
+	trayProfilesMenu.SetTitle(fmt.Sprintf("게임 프로필 (%d)", len(profiles)))
+}
+
+func (slot *profileMenuSlot) current() *Profile {
+	trayMu.Lock()
+	name := slot.profile
+	trayMu.Unlock()
+	return findProfile(name)
+}
+
+func (slot *profileMenuSlot) handleClicks() {
+	for {
+		var action func(p *Profile)
+		select {
+		case <-slot.backup.ClickedCh:
+			action = func(p *Profile) { backupProfile(p, triggerManual, "수동 백업") }
+		case <-slot.restore.ClickedCh:
+			action = func(p *Profile) {
+				if dialog.Message("%s 프로필의 가장 최근 백업으로 복원하시겠습니까?\n\n현재 세이브는 복원 전에 자동으로 백업됩니다.", p.Name).Title("백업 복원").YesNo() {
+					actionRestoreLatest(p)
+				}
+			}
+		case <-slot.open.ClickedCh:
+			action = openBackupFolder
+		}
+
+		if p := slot.current(); p != nil {
+			action(p)
+		}
+	}
+}
+
+var (
+	trayAutoItem   *systray.MenuItem
+	trayPauseMenu  *systray.MenuItem
+	trayResumeItem *systray.MenuItem
+)
+
+// setupAutoBackupMenu 자동 백업 켜기/끄기와 일시 중지 메뉴. 설정 파일 수정 없이 바로 적용
+func setupAutoBackupMenu() {
+	trayMu.Lock()
+	trayAutoItem = systray.AddMenuItemCheckbox("자동 백업", "세이브 파일이 바뀔 때마다 자동 백업", GetConfig().AutoBackup)
+	trayPauseMenu = systray.AddMenuItem("자동 백업 일시 중지", "자동 백업을 잠시 멈춤")
+	mPause15m := trayPauseMenu.AddSubMenuItem("15분", "15분 동안 자동 백업 중지")
+	mPause1h := trayPauseMenu.AddSubMenuItem("1시간", "1시간 동안 자동 백업 중지")
+	mPauseGame := trayPauseMenu.AddSubMenuItem("게임 종료까지", "실행 중인 게임이 종료될 때까지 자동 백업 중지")
+	trayResumeItem = trayPauseMenu.AddSubMenuItem("재개", "일시 중지 해제")
+	trayMu.Unlock()
+
+	onStatusChange(refreshAutoBackupMenu)
+	refreshAutoBackupMenu()
+
+	go func() {
+		for {
+			select {
+			case <-trayAutoItem.ClickedCh:
+				toggleAutoBackup()
+			case <-mPause15m.ClickedCh:
+				pauseAutoBackup(15 * time.Minute)
+			case <-mPause1h.ClickedCh:
+				pauseAutoBackup(time.Hour)
+			case <-mPauseGame.ClickedCh:
+				pauseAutoBackupUntilGameExit()
+			case <-trayResumeItem.ClickedCh:
+				resumeAutoBackup()
+			}
+		}
+	}()
+}
+
+// refreshAutoBackupMenu 체크 상태와 일시 중지 표시 갱신
+func refreshAutoBackupMenu() {
+	paused, until := autoBackupPauseStatus()
+
+	trayMu.Lock()
+	defer trayMu.Unlock()
+
+	if trayAutoItem == nil {
+		return
+	}
+
+	if GetConfig().AutoBackup {
+		trayAutoItem.Check()
+		trayPauseMenu.Enable()
+	} else {
+		trayAutoItem.Uncheck()
+		trayPauseMenu.Disable()
+	}
+
+	if paused {
+		trayPauseMenu.SetTitle(fmt.Sprintf("자동 백업 일시 중지 (%s)", until))
+		trayResumeItem.Enable()
+	} else {
+		trayPauseMenu.SetTitle("자동 백업 일시 중지")
+		trayResumeItem.Disable()
+	}
+}
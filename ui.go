@@ -1,59 +1,19 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/sqweek/dialog"
 )
 
-func showSettingsDialog() {
-	// 간단한 설정 대화상자 표시
-	// 실제 구현에서는 더 정교한 GUI를 사용할 수 있습니다
-
-	config := GetConfig()
-
-	// 대상 파일 경로 변경
-	if result := dialog.Message("대상 파일 경로를 변경하시겠습니까?\n현재: %s", config.TargetFile).YesNo(); result {
-		if newPath, err := dialog.File().Filter("Save Files", "sav").Load(); err == nil {
-			config.TargetFile = newPath
-		}
-	}
-
-	// 백업 디렉토리 변경
-	if result := dialog.Message("백업 디렉토리를 변경하시겠습니까?\n현재: %s", config.BackupDir).YesNo(); result {
-		if newDir, err := dialog.Directory().Browse(); err == nil {
-			config.BackupDir = newDir
-		}
-	}
-
-	// 자동 백업 토글
-	autoBackupText := "비활성화"
-	if config.AutoBackup {
-		autoBackupText = "활성화"
-	}
-	if result := dialog.Message("자동 백업을 토글하시겠습니까?\n현재: %s", autoBackupText).YesNo(); result {
-		config.AutoBackup = !config.AutoBackup
-	}
-
-	// 설정 저장
-	if err := saveConfig(config); err != nil {
-		dialog.Message("설정 저장에 실패했습니다: %v", err).Error()
-		return
-	}
-
-	dialog.Message("설정이 저장되었습니다.").Info()
-
-	// 파일 감시자 재시작
-	restartFileWatcher()
-
-	// 단축키 업데이트
-	updateHotkeys()
-}
-
-func openBackupFolder() {
-	backupDir := GetConfig().BackupDir
+// openBackupFolder 프로필의 백업 폴더 열기
+func openBackupFolder(p *Profile) {
+	backupDir := p.backupFolder()
 
 	switch runtime.GOOS {
 	case "windows":
@@ -67,10 +27,27 @@ func openBackupFolder() {
 	}
 }
 
+// openBackupLocation 탐색기에서 백업 파일 위치 열기 (가능하면 파일 선택)
+func openBackupLocation(backupPath string) {
+	switch runtime.GOOS {
+	case "windows":
+		exec.Command("explorer", "/select,", backupPath).Start()
+	case "darwin":
+		exec.Command("open", "-R", backupPath).Start()
+	case "linux":
+		exec.Command("xdg-open", filepath.Dir(backupPath)).Start()
+	default:
+		log.Printf("백업 폴더 열기를 지원하지 않는 운영체제입니다: %s", runtime.GOOS)
+	}
+}
+
+// openConfigFile 설정 파일을 편집기로 열기 (저장한 내용은 설정 파일 감시로 바로 적용)
 func openConfigFile() {
 	switch runtime.GOOS {
 	case "windows":
-		exec.Command("notepad", configPath).Start()
+		if err := exec.Command("notepad", configPath).Start(); err != nil {
+			log.Printf("설정 파일 열기 실패: %v", err)
+		}
 	case "darwin":
 		exec.Command("open", "-t", configPath).Start()
 	case "linux":
@@ -80,6 +57,45 @@ func openConfigFile() {
 	}
 }
 
+// openURL 기본 브라우저로 주소 열기
+func openURL(url string) {
+	switch runtime.GOOS {
+	case "windows":
+		exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
+	case "darwin":
+		exec.Command("open", url).Start()
+	case "linux":
+		exec.Command("xdg-open", url).Start()
+	default:
+		log.Printf("브라우저 열기를 지원하지 않는 운영체제입니다: %s", runtime.GOOS)
+	}
+}
+
+// promptText 한 줄 입력 대화상자. 취소하거나 빈 값이면 ok=false
+func promptText(title, message string) (string, bool) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+		script := fmt.Sprintf("Add-Type -AssemblyName Microsoft.VisualBasic; [Microsoft.VisualBasic.Interaction]::InputBox(%s, %s)",
+			quote(message), quote(title))
+		cmd = exec.Command("powershell", "-NoProfile", "-WindowStyle", "Hidden", "-Command", script)
+	case "linux":
+		cmd = exec.Command("zenity", "--entry", "--title", title, "--text", message)
+	default:
+		log.Printf("입력 대화상자를 지원하지 않는 운영체제입니다: %s", runtime.GOOS)
+		return "", false
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+
+	text := strings.TrimSpace(string(output))
+	return text, text != ""
+}
+
 func showAboutDialog() {
 	dialog.Message(`SB Backup Creator v0.0.3
 
@@ -0,0 +1,486 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sqweek/dialog"
+)
+
+// 설정 검사
+// 설정 파일의 문법/형식 오류와 값 오류(음수 개수, 잘못된 단축키, 감시 폴더 안의 백업 폴더 등)를
+// 항목 경로와 설정 파일 안의 줄/열 위치로 알림
+// 설정 창 저장, 처음 실행 마법사, 트레이 시작, 설정 파일 편집 후, config validate 명령에서 사용
+
+// configError 설정 항목 하나의 오류
+type configError struct {
+	Field   string // JSON 경로 (max_backups, profiles[0].target_file, hotkeys.ctrl+alt+f10)
+	Profile string // 추가 프로필 항목이면 프로필 이름
+	Message string
+	Line    int // 설정 파일 안의 위치 (알 수 없으면 0)
+	Column  int
+}
+
+func (e *configError) Error() string {
+	var b strings.Builder
+	if e.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d: ", e.Line, e.Column)
+	}
+	if e.Field != "" {
+		b.WriteString(e.Field)
+		if e.Profile != "" {
+			fmt.Fprintf(&b, " (%s)", e.Profile)
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// decodeConfig 설정 JSON을 기본값 위에 읽기. 문법/형식 오류는 줄/열 위치와 함께 반환
+// 주석과 끝 쉼표는 같은 길이의 공백으로 바꿔 읽으므로 오류 위치는 원문 기준
+func decodeConfig(data []byte) (*Config, error) {
+	plain := stripJSONC(data)
+	cfg := &Config{Notifications: defaultNotifications}
+	if err := json.Unmarshal(plain, cfg); err != nil {
+		return nil, jsonDecodeError(data, err)
+	}
+
+	// 모르는 항목은 저장할 때 다시 쓰도록 보관
+	cfg.extra = unknownFields(plain, configKeys)
+	var raw struct {
+		Profiles []json.RawMessage `json:"profiles"`
+	}
+	if json.Unmarshal(plain, &raw) == nil && len(raw.Profiles) == len(cfg.Profiles) {
+		for i := range cfg.Profiles {
+			cfg.Profiles[i].extra = unknownFields(raw.Profiles[i], profileKeys)
+		}
+	}
+
+	if cfg.Name == "" {
+		cfg.Name = defaultProfileName
+	}
+	if cfg.GameProcesses == nil {
+		cfg.GameProcesses = defaultGameProcesses
+	}
+	return cfg, nil
+}
+
+// jsonDecodeError encoding/json 오류를 위치가 있는 configError로 변환
+func jsonDecodeError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		// Offset은 오류가 난 문자 다음 위치
+		line, column := lineColumn(data, syntaxErr.Offset-1)
+		return &configError{Message: fmt.Sprintf("JSON 문법 오류: %v", syntaxErr), Line: line, Column: column}
+	case errors.As(err, &typeErr):
+		line, column := lineColumn(data, typeErr.Offset-1)
+		return &configError{
+			Field:   typeErr.Field,
+			Message: fmt.Sprintf("%s 값이어야 하는데 %s 값입니다", goTypeName(typeErr.Type.Kind().String()), jsonValueName(typeErr.Value)),
+			Line:    line,
+			Column:  column,
+		}
+	default:
+		return &configError{Message: err.Error()}
+	}
+}
+
+func goTypeName(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "숫자"
+	case kind == "string":
+		return "문자열"
+	case kind == "bool":
+		return "true/false"
+	case kind == "slice", kind == "array":
+		return "목록"
+	default:
+		return "객체"
+	}
+}
+
+func jsonValueName(value string) string {
+	kind, _, _ := strings.Cut(value, " ")
+	names := map[string]string{"string": "문자열", "number": "숫자", "bool": "true/false", "array": "목록", "object": "객체"}
+	if name, ok := names[kind]; ok {
+		return name
+	}
+	return value
+}
+
+// lineColumn 바이트 위치를 1부터 시작하는 줄/열로 (열은 문자 단위)
+func lineColumn(data []byte, offset int64) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	return line, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+// jsonValueOffsets JSON 경로(profiles[0].max_backups) → 값이 시작하는 바이트 위치
+func jsonValueOffsets(data []byte) map[string]int64 {
+	offsets := make(map[string]int64)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	indexJSONValue(dec, data, "", offsets)
+	return offsets
+}
+
+func indexJSONValue(dec *json.Decoder, data []byte, path string, offsets map[string]int64) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			offsets[child] = skipJSONSeparators(data, dec.InputOffset())
+			if err := indexJSONValue(dec, data, child, offsets); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; dec.More(); i++ {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			offsets[child] = skipJSONSeparators(data, dec.InputOffset())
+			if err := indexJSONValue(dec, data, child, offsets); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = dec.Token() // 닫는 괄호
+	return err
+}
+
+// skipJSONSeparators 공백, 쉼표, 콜론을 건너뛴 다음 값의 위치
+func skipJSONSeparators(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// locateConfigErrors 항목 경로로 설정 파일 안의 위치 채우기
+// 항목이 파일에 없으면 (기본값 사용) 가장 가까운 상위 항목의 위치
+func locateConfigErrors(data []byte, errs []error) {
+	offsets := jsonValueOffsets(stripJSONC(data))
+	for _, err := range errs {
+		var configErr *configError
+		if !errors.As(err, &configErr) || configErr.Line > 0 {
+			continue
+		}
+		for path := configErr.Field; path != ""; path = parentJSONPath(path) {
+			if offset, ok := offsets[path]; ok {
+				configErr.Line, configErr.Column = lineColumn(data, offset)
+				break
+			}
+		}
+	}
+}
+
+func parentJSONPath(path string) string {
+	if i := strings.LastIndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// validateConfigData 설정 파일 내용을 읽고 검사. 오류에는 줄/열 위치가 들어 있음
+func validateConfigData(data []byte) (*Config, []error) {
+	// 예전 버전의 파일은 변환한 내용으로 검사 (위치도 변환한 내용 기준)
+	migrated, _, err := migrateConfigData(data)
+	if err != nil {
+		// 변환 중 형식 오류는 원래 내용에서 위치를 찾아 표시
+		if _, decodeErr := decodeConfig(data); decodeErr != nil {
+			return nil, []error{decodeErr}
+		}
+		return nil, []error{&configError{Field: "version", Message: err.Error()}}
+	}
+	data = migrated
+
+	cfg, err := decodeConfig(data)
+	if err != nil {
+		return nil, []error{err}
+	}
+	errs := validateSettings(cfg)
+	locateConfigErrors(data, errs)
+	return cfg, errs
+}
+
+// checkConfigFile 현재 설정 파일 검사
+func checkConfigFile() []error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return []error{fmt.Errorf("설정 파일 읽기 실패: %v", err)}
+	}
+	_, errs := validateConfigData(data)
+	return errs
+}
+
+// showConfigProblems 설정 파일 오류를 기록하고 대화상자로 알림
+func showConfigProblems(errs []error) {
+	const maxShown = 10
+
+	lines := make([]string, 0, maxShown+1)
+	for i, err := range errs {
+		log.Printf("설정 파일 오류: %v", err)
+		if i < maxShown {
+			lines = append(lines, err.Error())
+		}
+	}
+	if len(errs) > maxShown {
+		lines = append(lines, fmt.Sprintf("... 외 %d개", len(errs)-maxShown))
+	}
+
+	go dialog.Message("%s 에 문제가 있습니다.\n\n%s", filepath.Base(configPath), strings.Join(lines, "\n")).Title("설정 파일 오류").Error()
+}
+
+// runConfigValidate config validate 명령: 설정 파일을 검사하고 오류를 "파일:줄:열: 항목: 내용" 형식으로 출력
+func runConfigValidate(path string) int {
+	if path == "" {
+		resolved, err := resolveConfigPath()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		path = resolved
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "설정 파일 읽기 실패: %v\n", err)
+		return 1
+	}
+
+	if _, from, err := migrateConfigData(data); err == nil && from < configVersion {
+		fmt.Printf("%s: 예전 버전(version %d)의 설정 파일입니다. 프로그램을 실행하면 version %d로 변환합니다 (줄:열은 변환한 내용 기준)\n", path, from, configVersion)
+	}
+	_, errs := validateConfigData(data)
+	printConfigErrors(os.Stdout, path, errs)
+	if len(errs) > 0 {
+		return 1
+	}
+	fmt.Printf("%s: 문제가 없습니다\n", path)
+	return 0
+}
+
+func printConfigErrors(w io.Writer, path string, errs []error) {
+	for _, err := range errs {
+		var configErr *configError
+		if errors.As(err, &configErr) && configErr.Line > 0 {
+			fmt.Fprintf(w, "%s:%v\n", path, err)
+		} else {
+			fmt.Fprintf(w, "%s: %v\n", path, err)
+		}
+	}
+}
+
+// validateSettings 저장 전에 설정 값 검사. 문제가 있으면 항목별 오류 목록 반환
+func validateSettings(cfg *Config) []error {
+	var errs []error
+	addError := func(field, profile, format string, args ...any) {
+		errs = append(errs, &configError{Field: field, Profile: profile, Message: fmt.Sprintf(format, args...)})
+	}
+
+	numbers := []struct {
+		name  string
+		value int64
+	}{
+		{"quick_slots", int64(cfg.QuickSlots)},
+		{"recent_backups", int64(cfg.RecentBackups)},
+		{"chord_timeout_ms", int64(cfg.ChordTimeoutMs)},
+		{"notifications.min_interval_seconds", int64(cfg.Notifications.MinIntervalSeconds)},
+	}
+	for _, number := range numbers {
+		if number.value < 0 {
+			addError(number.name, "", "0 이상이어야 합니다")
+		}
+	}
+
+	// 프로필마다 경로, 숫자, 검사 규칙 확인. 서로 다른 세이브의 백업 파일 이름이 겹치면 안 됨
+	names := make(map[string]bool)
+	backupNames := make(map[string]string)
+	for i, p := range profilesOf(cfg) {
+		prefix, profile := "", ""
+		if i > 0 {
+			prefix, profile = fmt.Sprintf("profiles[%d].", i-1), p.Name
+		}
+
+		if strings.TrimSpace(p.Name) == "" && i > 0 {
+			addError(prefix+"name", profile, "프로필 이름을 입력하세요")
+		} else if names[strings.ToLower(p.Name)] {
+			addError(prefix+"name", profile, "같은 이름의 프로필이 있습니다")
+		}
+		names[strings.ToLower(p.Name)] = true
+
+		if strings.TrimSpace(p.TargetFile) == "" {
+			addError(prefix+"target_file", profile, "세이브 파일 경로를 입력하세요")
+		}
+		if strings.TrimSpace(p.BackupDir) == "" {
+			addError(prefix+"backup_dir", profile, "백업 폴더를 입력하세요")
+		}
+		if p.MaxBackups < 0 {
+			addError(prefix+"max_backups", profile, "0 이상이어야 합니다")
+		}
+		if p.MinSaveSize < 0 {
+			addError(prefix+"min_save_size", profile, "0 이상이어야 합니다")
+		}
+		for j, name := range p.Validators {
+			if _, ok := saveValidators[name]; !ok {
+				addError(fmt.Sprintf("%svalidators[%d]", prefix, j), profile, "알 수 없는 검사입니다: %s", name)
+			}
+		}
+		for j, pattern := range p.GameProcesses {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				addError(fmt.Sprintf("%sgame_processes[%d]", prefix, j), profile, "%s: %v", pattern, err)
+			}
+		}
+		if err := p.resolvePaths(); err != nil {
+			addError(strings.TrimSuffix(prefix, "."), profile, "%v", err)
+			continue
+		}
+
+		if p.TargetFile == "" || p.BackupDir == "" {
+			continue
+		}
+		for _, target := range p.targets() {
+			key := strings.ToLower(filepath.Clean(target.backupPath("")))
+			if other, ok := backupNames[key]; ok && other != target.TargetFile {
+				addError(prefix+"backup_dir", profile, "%s 와 백업 파일 이름이 겹칩니다. backup_dir이나 backup_prefix를 바꾸세요", other)
+			}
+			backupNames[key] = target.TargetFile
+
+			// 백업 폴더가 감시하는 세이브 폴더 안에 있으면 감시가 자기 백업에 반응할 수 있음
+			if isInsideDir(target.BackupDir, filepath.Dir(target.TargetFile)) {
+				addError(prefix+"backup_dir", profile, "감시하는 세이브 폴더(%s) 안에 있습니다. 다른 폴더를 지정하세요", filepath.Dir(target.TargetFile))
+				break
+			}
+		}
+	}
+
+	// 단축키: 조합과 동작 확인, 같은 조합을 여러 프로필에 지정하면 하나만 등록되므로 오류
+	owners := make(map[string]string)
+	for _, source := range hotkeySources(cfg) {
+		steps := strings.Split(source.combo, ",")
+		var err error
+		if len(steps) == 1 {
+			if _, err = parseHotkeyCombo(source.combo); err == nil {
+				err = validateAction(source.action)
+			}
+		} else {
+			err = validateChordStep(steps, source.action)
+		}
+		if err != nil {
+			addError(source.field, source.profile, "%s → %s: %v", source.combo, source.action, err)
+		}
+
+		combo := canonicalCombo(source.combo)
+		owner := source.profile
+		if owner == "" {
+			owner = "기본"
+		}
+		if other, ok := owners[combo]; ok && other != owner {
+			addError(source.field, source.profile, "%s: %s 프로필과 %s 프로필에 모두 지정되어 있습니다", source.combo, other, owner)
+		}
+		owners[combo] = owner
+	}
+
+	for combo, fallbacks := range cfg.HotkeyFallbacks {
+		for i, fallback := range fallbacks {
+			if _, err := parseHotkeyCombo(fallback); err != nil {
+				addError(fmt.Sprintf("hotkey_fallbacks.%s[%d]", combo, i), "", "%v", err)
+			}
+		}
+	}
+
+	return errs
+}
+
+// hotkeySource 설정 파일의 단축키 항목 하나
+type hotkeySource struct {
+	field   string // JSON 경로
+	profile string // 추가 프로필의 단축키면 프로필 이름
+	combo   string
+	action  string
+}
+
+// hotkeySources 설정 파일에 적힌 모든 단축키 (기존 단일 항목, hotkeys, 프로필별 hotkeys)
+func hotkeySources(cfg *Config) []hotkeySource {
+	sources := []hotkeySource{
+		{field: "hotkey_combo", combo: cfg.HotkeyCombo, action: "backup"},
+		{field: "quick_save_hotkey", combo: cfg.QuickSaveHotkey, action: "quick_save"},
+		{field: "quick_load_hotkey", combo: cfg.QuickLoadHotkey, action: "quick_load"},
+		{field: "quick_next_hotkey", combo: cfg.QuickNextHotkey, action: "quick_next"},
+		{field: "quick_prev_hotkey", combo: cfg.QuickPrevHotkey, action: "quick_prev"},
+	}
+	for _, combo := range sortedKeys(cfg.Hotkeys) {
+		sources = append(sources, hotkeySource{field: "hotkeys." + combo, combo: combo, action: cfg.Hotkeys[combo]})
+	}
+	for i, p := range cfg.Profiles {
+		for _, combo := range sortedKeys(p.Hotkeys) {
+			sources = append(sources, hotkeySource{
+				field:   fmt.Sprintf("profiles[%d].hotkeys.%s", i, combo),
+				profile: p.Name,
+				combo:   combo,
+				action:  p.Hotkeys[combo],
+			})
+		}
+	}
+
+	result := sources[:0]
+	for _, source := range sources {
+		if source.combo != "" {
+			result = append(result, source)
+		}
+	}
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isInsideDir dir이 parent와 같거나 그 하위 폴더인지
+func isInsideDir(dir, parent string) bool {
+	rel, err := filepath.Rel(filepath.Clean(parent), filepath.Clean(dir))
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
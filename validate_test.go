@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLineColumn(t *testing.T) {
+	data := []byte("{\n  \"이름\": 1,\r\n\"b\"\n")
+	tests := []struct {
+		offset int64
+		line   int
+		column int
+	}{
+		{-1, 1, 1},
+		{0, 1, 1},
+		{1, 1, 2},
+		{2, 2, 1},
+		{4, 2, 3},
+		{11, 2, 6}, // 한글은 한 글자에 한 열
+		{18, 3, 1},
+		{100, 4, 1},
+	}
+	for _, tt := range tests {
+		line, column := lineColumn(data, tt.offset)
+		if line != tt.line || column != tt.column {
+			t.Errorf("lineColumn(%d) = %d:%d, want %d:%d", tt.offset, line, column, tt.line, tt.column)
+		}
+	}
+}
+
+func TestDecodeConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		field  string
+		line   int
+		column int
+	}{
+		{"syntax error", "{\n  \"max_backups\": 1,\n  \"name\": ]\n}", "", 3, 11},
+		{"syntax error after comment", "{\n  // \"x\": ]\n  \"a\" 1\n}", "", 3, 7},
+		{"type error", "{\n  \"max_backups\": \"ten\"\n}", "max_backups", 2, 22}, // 값의 마지막 문자
+		{"nested type error", "{\n  \"notifications\": {\"success\": 1}\n}", "notifications.success", 2, 32},
+	}
+	for _, tt := range tests {
+		_, err := decodeConfig([]byte(tt.data))
+		var configErr *configError
+		if !errors.As(err, &configErr) {
+			t.Errorf("%s: error = %v, want *configError", tt.name, err)
+			continue
+		}
+		if configErr.Field != tt.field || configErr.Line != tt.line || configErr.Column != tt.column {
+			t.Errorf("%s: got %s %d:%d, want %s %d:%d (%v)", tt.name, configErr.Field, configErr.Line, configErr.Column, tt.field, tt.line, tt.column, err)
+		}
+	}
+}
+
+// testConfigData 임시 폴더의 세이브/백업 경로를 쓰는 설정 파일 내용 (extra는 6번째 줄부터 들어감)
+func testConfigData(t *testing.T, extra string) []byte {
+	dir := t.TempDir()
+	save := filepath.Join(dir, "save", "save.sav")
+	backups := filepath.Join(dir, "backups")
+	return []byte(fmt.Sprintf("{\n  // 테스트 설정\n  \"version\": %d,\n  \"target_file\": %s,\n  \"backup_dir\": %s,\n%s\n}\n",
+		configVersion, strconv.Quote(save), strconv.Quote(backups), extra))
+}
+
+func TestValidateConfigData(t *testing.T) {
+	tests := []struct {
+		name    string
+		extra   string
+		field   string
+		profile string
+		line    int
+		column  int
+	}{
+		{
+			name:   "negative max_backups",
+			extra:  "  \"max_backups\": -1",
+			field:  "max_backups",
+			line:   6,
+			column: 18,
+		},
+		{
+			name:   "negative nested number",
+			extra:  "  /* 알림 */ \"notifications\": {\"min_interval_seconds\": -5}",
+			field:  "notifications.min_interval_seconds",
+			line:   6,
+			column: 54,
+		},
+		{
+			name:   "unknown hotkey",
+			extra:  "  \"hotkeys\": {\n    \"ctrl+nosuchkey\": \"backup\"\n  }",
+			field:  "hotkeys.ctrl+nosuchkey",
+			line:   7,
+			column: 23,
+		},
+		{
+			name:   "unknown hotkey action",
+			extra:  "  \"hotkeys\": {\"ctrl+alt+f10\": \"explode\"}",
+			field:  "hotkeys.ctrl+alt+f10",
+			line:   6,
+			column: 31,
+		},
+		{
+			name:    "profile error",
+			extra:   "  \"profiles\": [\n    {\"name\": \"B\", \"target_file\": \"b/b.sav\", \"backup_dir\": \"b-backups\", \"max_backups\": -1}\n  ]",
+			field:   "profiles[0].max_backups",
+			profile: "B",
+			line:    7,
+			column:  87,
+		},
+		{
+			name:    "profile without name",
+			extra:   "  \"profiles\": [\n    {\"target_file\": \"b/b.sav\", \"backup_dir\": \"b-backups\"}\n  ]",
+			field:   "profiles[0].name",
+			profile: "",
+			line:    7, // name 항목이 없으면 프로필 위치
+			column:  5,
+		},
+		{
+			name:   "unknown validator",
+			extra:  "  \"validators\": [\"gvas\", \"nosuchcheck\"]",
+			field:  "validators[1]",
+			line:   6,
+			column: 26,
+		},
+	}
+	for _, tt := range tests {
+		_, errs := validateConfigData(testConfigData(t, tt.extra))
+		if len(errs) != 1 {
+			t.Errorf("%s: got %d errors, want 1: %v", tt.name, len(errs), errs)
+			continue
+		}
+		var configErr *configError
+		if !errors.As(errs[0], &configErr) {
+			t.Errorf("%s: error = %v, want *configError", tt.name, errs[0])
+			continue
+		}
+		if configErr.Field != tt.field || configErr.Profile != tt.profile || configErr.Line != tt.line || configErr.Column != tt.column {
+			t.Errorf("%s: got %s (%s) %d:%d, want %s (%s) %d:%d", tt.name,
+				configErr.Field, configErr.Profile, configErr.Line, configErr.Column,
+				tt.field, tt.profile, tt.line, tt.column)
+		}
+	}
+}
+
+func TestValidateConfigDataValid(t *testing.T) {
+	extra := "  \"max_backups\": 5, // 끝 쉼표도 허용\n  \"hotkeys\": {\"ctrl+alt+f10\": \"backup\", \"ctrl+alt+b, r\": \"restore_latest\"},"
+	cfg, errs := validateConfigData(testConfigData(t, extra))
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if cfg.MaxBackups != 5 {
+		t.Errorf("MaxBackups = %d, want 5", cfg.MaxBackups)
+	}
+}
+
+func TestValidateBackupDirInsideSaveDir(t *testing.T) {
+	dir := t.TempDir()
+	data := fmt.Sprintf("{\n  \"version\": %d,\n  \"target_file\": %s,\n  \"backup_dir\": %s\n}\n",
+		configVersion, strconv.Quote(filepath.Join(dir, "save.sav")), strconv.Quote(filepath.Join(dir, "backups")))
+	_, errs := validateConfigData([]byte(data))
+	var configErr *configError
+	if len(errs) != 1 || !errors.As(errs[0], &configErr) || configErr.Field != "backup_dir" || configErr.Line != 4 {
+		t.Errorf("errors = %v, want backup_dir error on line 4", errs)
+	}
+}
+
+func TestConfigErrorString(t *testing.T) {
+	tests := []struct {
+		err  *configError
+		want string
+	}{
+		{&configError{Message: "m"}, "m"},
+		{&configError{Field: "max_backups", Message: "m"}, "max_backups: m"},
+		{&configError{Field: "profiles[0].name", Profile: "B", Message: "m", Line: 3, Column: 5}, "3:5: profiles[0].name (B): m"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+
+	var buf bytes.Buffer
+	printConfigErrors(&buf, "settings.json", []error{tests[2].err, tests[1].err})
+	want := "settings.json:3:5: profiles[0].name (B): m\nsettings.json: max_backups: m\n"
+	if buf.String() != want {
+		t.Errorf("printConfigErrors = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestIsInsideDir(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "save")
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{root, true},
+		{filepath.Join(root, "backups"), true},
+		{filepath.Join(root, "a", "b"), true},
+		{root + "_backups", false},
+		{filepath.Dir(root), false},
+		{filepath.Join(filepath.Dir(root), "..backups"), false},
+	}
+	for _, tt := range tests {
+		if got := isInsideDir(tt.dir, root); got != tt.want {
+			t.Errorf("isInsideDir(%q, %q) = %v, want %v", strings.TrimPrefix(tt.dir, root), root, got, tt.want)
+		}
+	}
+}
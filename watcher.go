@@ -1,115 +1,250 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
 
+// 게임은 세이브를 여러 번에 나눠 쓰거나 임시 파일에 쓴 뒤 이름을 바꿔 덮어쓰므로
+// 쓰기 이벤트마다 백업하지 않고 저장이 끝났을 때 한 번만 백업
+const (
+	// 쓰기 완료 이벤트가 없으면 (Windows, macOS) 쓰기가 이 시간 동안 없을 때 저장이 끝난 것으로 봄
+	saveSettleDelay = 2 * time.Second
+	// 쓰기 완료 이벤트 (Linux) 뒤 같은 저장에서 이어지는 이벤트를 모으는 시간
+	completedSaveDelay = 200 * time.Millisecond
+)
+
 var (
+	// 실행 중인 감시. 다시 시작하면 이전 감시의 고루틴은 자기 감시자와 done만 쓰고 끝남
+	watcherMu   sync.Mutex
 	watcher     *fsnotify.Watcher
 	watcherDone chan bool
-	lastBackup  time.Time
+
+	lastWriteMu     sync.Mutex
+	lastTargetWrite = make(map[string]time.Time)
 )
 
+// startFileWatcher 세이브 폴더 감시 시작 (실행 중인 감시가 있으면 멈추고 새로 시작)
 func startFileWatcher() {
-	var err error
-	watcher, err = fsnotify.NewWatcher()
+	w, err := fsnotify.NewWatcher()
 	if err != nil {
 		log.Printf("파일 감시자 생성 실패: %v", err)
+		reportError(fmt.Errorf("파일 감시자 생성 실패: %v", err))
 		return
 	}
+	done := make(chan bool)
+
+	watcherMu.Lock()
+	stopWatcherLocked()
+	watcher, watcherDone = w, done
+	watcherMu.Unlock()
+
+	// 감시할 세이브마다 폴더 추가 (여러 계정을 감시하면 계정 폴더마다)
+	// 한 세이브의 폴더가 없거나 추가에 실패해도 나머지는 감시하고, 없는 폴더만 생길 때까지 기다림
+	targets := watchTargets()
+	primaryFile := targets[0].TargetFile
+
+	// 기본 프로필의 세이브 파일이 없으면 대상 없음으로 표시 (폴더는 있지만 파일이 아직 없는 경우 포함)
+	_, statErr := os.Stat(primaryFile)
+	setTargetMissing(primaryFile == "" || statErr != nil)
+
+	watched := make(map[string]saveTarget)
+	added := make(map[string]bool) // 폴더 → 감시 추가 성공 여부
+	var dirs, missing []string
+	for _, target := range targets {
+		if target.TargetFile == "" {
+			log.Println(targetMessage(target, "세이브 파일이 지정되지 않았습니다. 설정에서 target_file을 지정하세요"))
+			continue
+		}
 
-	watcherDone = make(chan bool)
-
-	// 감시할 파일의 디렉토리 추가
-	targetFile := GetConfig().TargetFile
-	targetDir := filepath.Dir(targetFile)
-
-	// 디렉토리가 존재하는지 확인
-	if _, err := os.Stat(targetDir); os.IsNotExist(err) {
-		log.Printf("대상 디렉토리가 존재하지 않습니다: %s", targetDir)
-		// 디렉토리가 생성될 때까지 주기적으로 확인
-		go waitForDirectory(targetDir)
-		return
+		dir := filepath.Dir(target.TargetFile)
+		ok, seen := added[dir]
+		if !seen {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				log.Printf("대상 디렉토리가 존재하지 않습니다: %s", dir)
+				missing = append(missing, dir)
+			} else if err := w.Add(dir); err != nil {
+				log.Printf("디렉토리 감시 추가 실패 (%s): %v", dir, err)
+				reportError(fmt.Errorf("디렉토리 감시 추가 실패: %v", err))
+			} else {
+				ok = true
+				dirs = append(dirs, dir)
+			}
+			added[dir] = ok
+		}
+		if !ok {
+			continue
+		}
+		watched[filepath.Clean(target.TargetFile)] = target
+		log.Printf("파일 감시 시작: %s", target.TargetFile)
 	}
 
-	err = watcher.Add(targetDir)
-	if err != nil {
-		log.Printf("디렉토리 감시 추가 실패: %v", err)
-		return
+	// 없는 폴더가 생기면 감시를 다시 시작
+	if len(missing) > 0 {
+		go waitForDirectories(missing, done)
 	}
 
-	log.Printf("파일 감시 시작: %s", targetFile)
+	var completed <-chan string
+	if len(dirs) > 0 {
+		completed, err = watchCompletedWrites(dirs, done)
+		if err != nil {
+			log.Printf("쓰기 완료 감지를 사용할 수 없어 쓰기가 멈출 때까지 기다립니다: %v", err)
+		}
+	}
 
-	go func() {
-		defer watcher.Close()
+	go func(w *fsnotify.Watcher, done chan bool) {
+		defer w.Close()
+
+		// 저장이 끝나기를 기다리는 세이브 → 백업할 시각
+		pending := make(map[string]time.Time)
+		var settle <-chan time.Time
+		rearm := func() {
+			var next time.Time
+			for _, due := range pending {
+				if next.IsZero() || due.Before(next) {
+					next = due
+				}
+			}
+			settle = nil
+			if !next.IsZero() {
+				settle = time.After(time.Until(next))
+			}
+		}
+		changed := func(name string, delay time.Duration) {
+			if _, waiting := pending[name]; !waiting {
+				log.Printf("파일 변경 감지: %s", name)
+			}
+			markTargetWrite(name)
+			if name == filepath.Clean(primaryFile) {
+				setTargetMissing(false)
+			}
+			pending[name] = time.Now().Add(delay)
+			rearm()
+		}
 
 		for {
 			select {
-			case event, ok := <-watcher.Events:
+			case event, ok := <-w.Events:
 				if !ok {
 					return
 				}
 
-				// 대상 파일이 변경된 경우만 처리
-				if event.Name == targetFile {
-					if event.Op&fsnotify.Write == fsnotify.Write {
-						log.Printf("파일 변경 감지: %s", event.Name)
+				// 대상 파일에 쓰거나, 새로 만들거나, 다른 파일을 이름 바꿔 덮어쓴 경우 (덮어쓰면 Create로 옴)
+				// 대상 파일이 다른 이름으로 바뀌거나 지워진 것(Rename, Remove)은 새 파일이 생길 때까지 기다림
+				name := filepath.Clean(event.Name)
+				if _, ok := watched[name]; !ok || !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+					continue
+				}
+				if completed != nil {
+					// 쓰기 완료 이벤트로 백업하므로 쓰는 중이라는 것만 기록
+					markTargetWrite(name)
+					continue
+				}
+				changed(name, saveSettleDelay)
+
+			case path, ok := <-completed:
+				if !ok {
+					completed = nil
+					continue
+				}
+				name := filepath.Clean(path)
+				if _, ok := watched[name]; ok {
+					changed(name, completedSaveDelay)
+				}
 
-						// 너무 자주 백업하는 것을 방지하기 위한 디바운싱
-						if time.Since(lastBackup) > 5*time.Second {
-							go performAutoBackup()
-							lastBackup = time.Now()
-						}
+			case <-settle:
+				now := time.Now()
+				for name, due := range pending {
+					if now.Before(due) {
+						continue
+					}
+					delete(pending, name)
+					// 이름을 바꿔 덮어쓰는 중이라 잠시 없으면 새 파일의 이벤트를 기다림
+					if _, err := os.Stat(name); err != nil {
+						continue
+					}
+					log.Printf("세이브 저장 완료: %s", name)
+					target, ok := currentWatchTarget(watched[name])
+					if !ok {
+						log.Printf("설정에서 빠진 세이브라 백업하지 않습니다: %s", name)
+						continue
 					}
+					go performAutoBackup(target)
 				}
+				rearm()
 
-			case err, ok := <-watcher.Errors:
+			case err, ok := <-w.Errors:
 				if !ok {
 					return
 				}
 				log.Printf("파일 감시 오류: %v", err)
+				reportError(fmt.Errorf("파일 감시 오류: %v", err))
 
-			case <-watcherDone:
+			case <-done:
 				return
 			}
 		}
-	}()
+	}(w, done)
 }
 
-func waitForDirectory(targetDir string) {
+func markTargetWrite(targetFile string) {
+	lastWriteMu.Lock()
+	lastTargetWrite[targetFile] = time.Now()
+	lastWriteMu.Unlock()
+}
+
+// sinceLastTargetWrite 감시 중 마지막으로 대상 파일 쓰기가 감지된 후 지난 시간
+func sinceLastTargetWrite(targetFile string) time.Duration {
+	lastWriteMu.Lock()
+	defer lastWriteMu.Unlock()
+	return time.Since(lastTargetWrite[targetFile])
+}
+
+// waitForDirectories 없는 세이브 폴더 중 하나라도 생길 때까지 주기적으로 확인하고 감시를 다시 시작
+func waitForDirectories(dirs []string, done chan bool) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			if _, err := os.Stat(targetDir); err == nil {
-				log.Printf("대상 디렉토리 생성됨. 파일 감시 시작: %s", targetDir)
-				startFileWatcher()
-				return
+			for _, dir := range dirs {
+				if _, err := os.Stat(dir); err == nil {
+					log.Printf("대상 디렉토리 생성됨. 파일 감시 다시 시작: %s", dir)
+					startFileWatcher()
+					return
+				}
 			}
-		case <-watcherDone:
+		case <-done:
 			return
 		}
 	}
 }
 
 func stopFileWatcher() {
+	watcherMu.Lock()
+	defer watcherMu.Unlock()
+	stopWatcherLocked()
+}
+
+// stopWatcherLocked 실행 중인 감시 멈춤 (watcherMu를 잡은 상태에서 호출, 여러 번 불러도 됨)
+func stopWatcherLocked() {
 	if watcherDone != nil {
 		close(watcherDone)
+		watcherDone = nil
 	}
 	if watcher != nil {
 		watcher.Close()
+		watcher = nil
 	}
 }
 
 func restartFileWatcher() {
-	stopFileWatcher()
-	time.Sleep(1 * time.Second) // 잠시 대기
 	startFileWatcher()
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// watchCompletedWrites inotify로 폴더 안의 파일 쓰기가 끝난 것을 감지
+// IN_CLOSE_WRITE: 쓰기용으로 연 파일을 닫음, IN_MOVED_TO: 다 쓴 임시 파일을 이름 바꿔 덮어씀
+// 완성된 파일 경로를 보내고, done이 닫히면 감시를 끝내고 채널을 닫음
+func watchCompletedWrites(dirs []string, done chan bool) (<-chan string, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("inotify 생성 실패: %v", err)
+	}
+
+	watches := make(map[int32]string)
+	for _, dir := range dirs {
+		wd, err := unix.InotifyAddWatch(fd, dir, unix.IN_CLOSE_WRITE|unix.IN_MOVED_TO)
+		if err != nil {
+			log.Printf("쓰기 완료 감시 추가 실패 (%s): %v", dir, err)
+			continue
+		}
+		watches[int32(wd)] = dir
+	}
+	if len(watches) == 0 {
+		unix.Close(fd)
+		return nil, fmt.Errorf("쓰기 완료를 감시할 폴더가 없습니다")
+	}
+
+	// 논블로킹 fd라 런타임 폴러로 읽으므로 Close하면 대기 중인 Read가 끝남
+	file := os.NewFile(uintptr(fd), "inotify")
+	completed := make(chan string)
+	go func() {
+		<-done
+		file.Close()
+	}()
+
+	go func() {
+		defer close(completed)
+
+		var buf [unix.SizeofInotifyEvent * 64 * 2]byte
+		for {
+			n, err := file.Read(buf[:])
+			if err != nil {
+				return
+			}
+
+			for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+				event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+				nameStart := offset + unix.SizeofInotifyEvent
+				nameEnd := min(nameStart+int(event.Len), n)
+				offset = nameEnd
+
+				dir, ok := watches[event.Wd]
+				name := string(bytes.TrimRight(buf[nameStart:nameEnd], "\x00"))
+				if !ok || name == "" {
+					continue
+				}
+				select {
+				case completed <- filepath.Join(dir, name):
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return completed, nil
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package main
+
+// watchCompletedWrites 쓰기 완료 이벤트가 없는 운영체제는 쓰기가 멈출 때까지 기다리는 방식만 사용
+func watchCompletedWrites(dirs []string, done chan bool) (<-chan string, error) {
+	return nil, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// useTestConfig 테스트 동안 cfg를 현재 설정으로 쓰고 상태 파일(카탈로그 등)은 임시 폴더에 둠
+func useTestConfig(t *testing.T, cfg *Config) {
+	t.Helper()
+	stateDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateDir)
+	t.Setenv("LOCALAPPDATA", stateDir)
+
+	if err := resolveProfilePaths(cfg); err != nil {
+		t.Fatal(err)
+	}
+	previous := currentConfig.Swap(cfg)
+	t.Cleanup(func() {
+		stopFileWatcher()
+		currentConfig.Store(previous)
+	})
+}
+
+// testProfile 임시 폴더 아래의 세이브/백업 경로를 쓰는 프로필
+func testProfile(root, name string) Profile {
+	return Profile{
+		Name:       name,
+		TargetFile: filepath.Join(root, name, "save", "save.sav"),
+		BackupDir:  filepath.Join(root, name, "backups"),
+	}
+}
+
+// waitForFile 감시가 만든 백업 파일이 생길 때까지 기다림 (쓰기 완료 이벤트가 없으면 saveSettleDelay 뒤에 백업)
+func waitForFile(t *testing.T, path string) {
+	t.Helper()
+	deadline := time.Now().Add(saveSettleDelay + 5*time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("backup not created: %s", path)
+}
+
+func writeSave(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestWatcherMissingPrimaryDir 기본 프로필의 세이브 폴더가 없어도 다른 프로필의 세이브는 감시
+func TestWatcherMissingPrimaryDir(t *testing.T) {
+	root := t.TempDir()
+	cfg := &Config{
+		Profile:    testProfile(root, "primary"),
+		Profiles:   []Profile{testProfile(root, "second")},
+		AutoBackup: true,
+	}
+	useTestConfig(t, cfg)
+
+	second := &cfg.Profiles[0]
+	if err := os.MkdirAll(filepath.Dir(second.targetPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	startFileWatcher()
+	writeSave(t, second.targetPath(), "second save")
+
+	waitForFile(t, filepath.Join(second.backupFolder(), "save_auto_0.sav"))
+}
+
+// TestWatcherBackupDirChange 감시 중에 backup_dir, backup_prefix를 바꾸면 감시를 다시 시작하지 않아도 다음 자동 백업부터 새 폴더, 새 이름으로 저장
+func TestWatcherBackupDirChange(t *testing.T) {
+	root := t.TempDir()
+	cfg := &Config{Profile: testProfile(root, "primary"), AutoBackup: true}
+	useTestConfig(t, cfg)
+
+	oldDir := cfg.Profile.backupFolder()
+	writeSave(t, cfg.Profile.targetPath(), "first")
+	startFileWatcher()
+	writeSave(t, cfg.Profile.targetPath(), "second")
+	waitForFile(t, filepath.Join(oldDir, "save_auto_0.sav"))
+
+	updated := cfg.clone()
+	updated.BackupDir = filepath.Join(root, "moved")
+	updated.BackupPrefix = "renamed"
+	if err := resolveProfilePaths(updated); err != nil {
+		t.Fatal(err)
+	}
+	if watchChanged(updated, cfg) {
+		t.Fatal("backup_dir change should not need a watcher restart")
+	}
+	publishConfig(updated)
+
+	writeSave(t, cfg.Profile.targetPath(), "third")
+	waitForFile(t, filepath.Join(root, "moved", "renamed_auto_0.sav"))
+	if _, err := os.Stat(filepath.Join(oldDir, "save_auto_1.sav")); err == nil {
+		t.Errorf("auto backup still rotated in the old backup_dir")
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 처음 실행 마법사
+// 찾을 수 있는 세이브 위치(여러 Steam ID, Proton/Wine 접두사)를 모두 보여주고 선택하게 한 뒤
+// 백업 폴더 쓰기 권한을 확인하고, 단축키를 보여주고, 기준 백업을 하나 만듦
+
+const saveFileName = "StellarBladeSave00.sav"
+
+// Stellar Blade의 Steam 앱 ID (Proton 접두사 폴더 이름)
+const stellarBladeAppID = "3489700"
+
+// saveCandidate 찾은 세이브 파일 위치 하나
+type saveCandidate struct {
+	TargetFile  string    `json:"target_file"`
+	BackupDir   string    `json:"backup_dir"`             // 같은 SB 폴더 아래 Backups
+	Source      string    `json:"source"`                 // "Steam", "Proton (앱 3489700)" 등
+	Account     string    `json:"account"`                // Steam ID 또는 계정 폴더 이름
+	SteamID     bool      `json:"steam_id"`               // Account가 숫자로만 된 Steam ID인지
+	AccountName string    `json:"account_name,omitempty"` // Steam 프로필 이름
+	ModTime     time.Time `json:"mod_time"`
+	Size        int64     `json:"size"`
+}
+
+// saveRoot 세이브 폴더(SaveGames)와 출처
+type saveRoot struct {
+	dir    string
+	source string
+}
+
+// findSaveCandidates 찾은 세이브 파일 목록 (최근 수정 순)
+func findSaveCandidates() []saveCandidate {
+	var candidates []saveCandidate
+	seen := make(map[string]bool)
+
+	for _, root := range saveSearchRoots() {
+		paths, _ := filepath.Glob(filepath.Join(root.dir, "*", saveFileName))
+		paths = append(paths, filepath.Join(root.dir, saveFileName))
+
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() {
+				continue
+			}
+
+			// ~/.steam/steam 같은 심볼릭 링크로 같은 파일이 여러 번 잡히지 않도록
+			key := path
+			if resolved, err := filepath.EvalSymlinks(path); err == nil {
+				key = resolved
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			account := ""
+			if dir := filepath.Dir(path); dir != filepath.Clean(root.dir) {
+				account = filepath.Base(dir)
+			}
+			candidates = append(candidates, saveCandidate{
+				TargetFile:  path,
+				BackupDir:   filepath.Clean(filepath.Join(root.dir, "..", "..", "Backups")),
+				Source:      root.source,
+				Account:     account,
+				SteamID:     isNumeric(account),
+				AccountName: steamAccountName(account),
+				ModTime:     info.ModTime(),
+				Size:        info.Size(),
+			})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ModTime.After(candidates[j].ModTime)
+	})
+	return candidates
+}
+
+// saveSearchRoots 운영체제별로 세이브 폴더가 있을 수 있는 위치
+// Windows면 이 PC의 LOCALAPPDATA, Linux면 Proton/Wine 접두사마다 안의 LOCALAPPDATA
+func saveSearchRoots() []saveRoot {
+	var roots []saveRoot
+	for _, ctx := range windowsContexts() {
+		dir, ok := ctx.resolve("<winLocalAppData>/SB/Saved/SaveGames")
+		if !ok {
+			continue
+		}
+		source := ctx.source
+		if ctx.prefix == "" || ctx.appID == stellarBladeAppID {
+			source = "Steam"
+			if ctx.prefix != "" {
+				source = "Proton (Steam)"
+			}
+		}
+		roots = append(roots, saveRoot{dir, source})
+	}
+	return roots
+}
+
+var libraryPathPattern = regexp.MustCompile(`"path"\s+"([^"]+)"`)
+
+// steamLibraries Steam 설치 폴더와 libraryfolders.vdf에 등록된 라이브러리 폴더
+func steamLibraries() []string {
+	var libraries []string
+	for _, root := range steamInstallDirs() {
+		if _, err := os.Stat(root); err != nil {
+			continue
+		}
+		libraries = append(libraries, root)
+
+		data, err := os.ReadFile(filepath.Join(root, "steamapps", "libraryfolders.vdf"))
+		if err != nil {
+			continue
+		}
+		for _, match := range libraryPathPattern.FindAllStringSubmatch(string(data), -1) {
+			libraries = append(libraries, strings.ReplaceAll(match[1], `\\`, `\`))
+		}
+	}
+	return libraries
+}
+
+// checkBackupDirWritable 백업 폴더를 만들고 임시 파일을 써서 쓰기 권한 확인
+func checkBackupDirWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("백업 폴더를 만들 수 없습니다: %v", err)
+	}
+
+	file, err := os.CreateTemp(dir, ".write-test-*")
+	if err != nil {
+		return fmt.Errorf("백업 폴더에 쓸 수 없습니다: %v", err)
+	}
+	name := file.Name()
+	_, err = file.Write([]byte("ok"))
+	file.Close()
+	os.Remove(name)
+	if err != nil {
+		return fmt.Errorf("백업 폴더에 쓸 수 없습니다: %v", err)
+	}
+	return nil
+}
+
+// showSetupWizard 처음 실행 마법사 열기
+func showSetupWizard() {
+	openSettingsPage("/wizard")
+}
+
+// handleWizardAPI GET: 세이브 후보와 현재 설정, POST: 선택한 위치로 설정 저장 후 기준 백업
+func handleWizardAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Token") != settingsToken {
+		writeSettingsResponse(w, http.StatusForbidden, map[string]string{"error": "잘못된 토큰입니다"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeSettingsResponse(w, http.StatusOK, map[string]any{
+			"candidates": findSaveCandidates(),
+			"config":     GetConfig(),
+		})
+
+	case http.MethodPost:
+		var choice struct {
+			TargetFile       string `json:"target_file"`
+			BackupDir        string `json:"backup_dir"`
+			WatchAllAccounts bool   `json:"watch_all_accounts"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&choice); err != nil {
+			writeSettingsResponse(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("요청을 읽을 수 없습니다: %v", err)})
+			return
+		}
+
+		result, err := finishSetupWizard(choice.TargetFile, choice.BackupDir, choice.WatchAllAccounts)
+		if err != nil {
+			writeSettingsResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		writeSettingsResponse(w, http.StatusOK, result)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// wizardResult 마법사 완료 화면에 보여줄 내용
+type wizardResult struct {
+	Hotkeys     []string `json:"hotkeys"`
+	Backup      string   `json:"backup"`
+	BackupError string   `json:"backup_error,omitempty"`
+}
+
+// finishSetupWizard 선택한 세이브 위치와 백업 폴더를 확인하고 저장한 뒤 기준 백업 생성
+func finishSetupWizard(targetFile, backupDir string, watchAllAccounts bool) (wizardResult, error) {
+	// 경로는 입력한 형태(변수 포함) 그대로 저장하고, 확인은 치환한 경로로
+	cfg := GetConfig().clone()
+	cfg.TargetFile = strings.TrimSpace(targetFile)
+	cfg.BackupDir = strings.TrimSpace(backupDir)
+	cfg.WatchAllAccounts = watchAllAccounts
+	if errs := validateSettings(cfg); len(errs) > 0 {
+		return wizardResult{}, errs[0]
+	}
+
+	if info, err := os.Stat(cfg.targetPath()); err != nil || info.IsDir() {
+		return wizardResult{}, fmt.Errorf("세이브 파일을 찾을 수 없습니다: %s", cfg.targetPath())
+	}
+	if err := checkBackupDirWritable(cfg.backupFolder()); err != nil {
+		return wizardResult{}, err
+	}
+
+	if err := applySettings(cfg); err != nil {
+		return wizardResult{}, err
+	}
+
+	var result wizardResult
+	for _, status := range hotkeyStatusList() {
+		result.Hotkeys = append(result.Hotkeys, status.String())
+	}
+
+	// 여러 계정을 감시하면 계정마다 기준 백업을 만들고, 완료 화면에는 선택한 계정의 결과를 표시
+	for i, target := range defaultProfile().targets() {
+		backupPath, err := createBackup(target, triggerBaseline, "")
+		if err != nil {
+			log.Printf("기준 백업 실패: %v", targetMessage(target, err.Error()))
+		} else {
+			log.Printf("기준 백업 완료: %s", backupPath)
+		}
+		if i > 0 {
+			continue
+		}
+		if err != nil {
+			result.BackupError = err.Error()
+		} else {
+			result.Backup = backupPath
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+// runLaunchWrapper 게임 실행 전 백업, 실행 중 감시, 종료 후 최종 백업을 수행하고
+// 게임의 종료 코드를 그대로 반환
+// 백업 도구의 오류로 게임 실행이 막히지 않도록 설정/백업 실패는 기록만 함
+// profileName이 비어 있으면 기본 프로필
+func runLaunchWrapper(profileName string, command []string) int {
+	var profile *Profile
+	if err := initializeConfig(); err != nil {
+		log.Printf("설정 초기화 실패, 백업 없이 게임만 실행합니다: %v", err)
+	} else if profileName == "" {
+		profile = defaultProfile()
+	} else if profile = findProfile(profileName); profile == nil {
+		log.Printf("프로필을 찾을 수 없어 백업 없이 게임만 실행합니다: %s", profileName)
+	}
+	configReady := profile != nil
+
+	if configReady {
+		session := beginGameSession(profile.Name)
+		log.Printf("게임 실행 전 백업: %s (세션 %s)", profile.Name, session)
+		backupProfile(profile, triggerSessionStart, "실행 전 백업")
+
+		// 게임이 바로 끝나도 stopFileWatcher가 감시를 멈출 수 있도록 시작을 기다림
+		startFileWatcher()
+	}
+
+	exitCode := runGameProcess(command)
+
+	if configReady {
+		stopFileWatcher()
+
+		backupProfile(profile, triggerSessionEnd, "종료 후 백업")
+		endGameSession(profile.Name)
+	}
+
+	return exitCode
+}
+
+// runGameProcess 게임을 자식 프로세스로 실행하고 종료 코드 반환
+func runGameProcess(command []string) int {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		log.Printf("게임 실행 실패: %v", err)
+		return 127
+	}
+
+	// 종료 신호는 게임에 전달하고 게임이 끝날 때까지 대기
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		for sig := range signals {
+			cmd.Process.Signal(sig)
+		}
+	}()
+
+	err := cmd.Wait()
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		return exitErr.ExitCode()
+	}
+
+	log.Printf("게임 프로세스 대기 실패: %v", err)
+	return 1
+}